@@ -0,0 +1,52 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/config"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "config",
+		summary: "print the effective configuration",
+		run:     runConfig,
+	})
+}
+
+// runConfig handles `config print [flags]`, where flags are the flags of the
+// server. The configuration is printed as YAML, with the secrets masked, and
+// the command fails when it is invalid.
+func runConfig(args []string) error {
+	if len(args) == 0 || args[0] != "print" {
+		return errors.New("usage: config print [-config file] [server flags]")
+	}
+
+	cfg, err := config.Load(args[1:])
+	if err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(os.Stdout)
+	encoder.SetIndent(2)
+	if err = encoder.Encode(cfg.Masked()); err != nil {
+		return err
+	}
+	if err = encoder.Close(); err != nil {
+		return err
+	}
+
+	if err = cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	return nil
+}
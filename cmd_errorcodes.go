@@ -0,0 +1,53 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"os"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/errorcode"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "errorcodes",
+		summary: "export the error code catalog as JSON",
+		run:     runErrorCodes,
+	})
+}
+
+// runErrorCodes handles `errorcodes export [-output file]`.
+func runErrorCodes(args []string) error {
+	if len(args) == 0 || args[0] != "export" {
+		return errors.New("usage: errorcodes export [-output file]")
+	}
+
+	flags := flag.NewFlagSet("errorcodes export", flag.ContinueOnError)
+	output := flags.String("output", "", "file to write the catalog to, defaults to stdout")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(struct {
+		ErrorCodes []errorcode.Entry `json:"errorCodes"`
+	}{errorcode.All()})
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/config"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/quarantine"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "quarantine",
+		summary: "list and fetch the quarantined payloads of rejected records",
+		run:     runQuarantine,
+	})
+}
+
+// quarantineEntryView is a quarantine.Entry whose payload is replaced when
+// printed.
+type quarantineEntryView quarantine.Entry
+
+const quarantineUsage = "usage: quarantine list [-namespace ns] | quarantine fetch [-payload] [-output file] <id>"
+
+// runQuarantine handles `quarantine list` and `quarantine fetch`, reading the
+// quarantine configured by the QUARANTINE_* environment variables.
+func runQuarantine(args []string) error {
+	if len(args) == 0 {
+		return errors.New(quarantineUsage)
+	}
+
+	cfg, err := config.Load(nil)
+	if err != nil {
+		return err
+	}
+	q, err := newQuarantine(cfg.Quarantine)
+	if err != nil {
+		return err
+	}
+	if q == nil {
+		return errors.New("QUARANTINE_STORE is not set")
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "list":
+		flags := flag.NewFlagSet("quarantine list", flag.ContinueOnError)
+		namespace := flags.String("namespace", "", "only list the entries of namespace")
+		if err = flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		infos, err := q.List(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tSIZE\tSTORED")
+		for _, info := range infos {
+			if *namespace != "" && !strings.HasPrefix(info.ID, *namespace+"/") {
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\n", info.ID, info.Size, info.ModTime.UTC().Format(time.RFC3339))
+		}
+
+		return w.Flush()
+	case "fetch":
+		flags := flag.NewFlagSet("quarantine fetch", flag.ContinueOnError)
+		payloadOnly := flags.Bool("payload", false, "write the raw payload instead of the entry")
+		output := flags.String("output", "", "file to write to, defaults to stdout")
+		if err = flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if flags.NArg() != 1 {
+			return errors.New(quarantineUsage)
+		}
+
+		entry, err := q.Get(ctx, flags.Arg(0))
+		if err != nil {
+			return err
+		}
+
+		var w io.Writer = os.Stdout
+		if *output != "" {
+			f, err := os.Create(*output)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+
+		if *payloadOnly {
+			_, err = w.Write(entry.Payload)
+
+			return err
+		}
+
+		// The payload is JSON, so it is shown as is rather than base64 encoded
+		view := struct {
+			*quarantineEntryView
+			Payload json.RawMessage `json:"payload,omitempty"`
+		}{quarantineEntryView: (*quarantineEntryView)(entry)}
+		if json.Valid(entry.Payload) {
+			view.Payload = entry.Payload
+		} else if len(entry.Payload) > 0 {
+			view.Payload, _ = json.Marshal(string(entry.Payload))
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(view)
+	default:
+		return errors.New(quarantineUsage)
+	}
+}
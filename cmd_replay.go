@@ -0,0 +1,152 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/capture"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/config"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/server"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "replay",
+		summary: "replay captured calls and report the results that changed",
+		run:     runReplay,
+	})
+}
+
+// runReplay handles `replay [flags] <capture file>...`. It fails when a
+// replayed result differs from the captured one.
+func runReplay(args []string) error {
+	cfg, err := config.Load(nil)
+	if err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	formatName := flags.String("format", string(capture.FormatProtobuf), "format of the capture files, protobuf or json")
+	target := flags.String("target", "", "address of the validator to replay against, defaults to an in-process validator")
+	token := flags.String("token", "", "bearer token sent to the target")
+	rules := flags.String("rules", cfg.Rules.File, "rule set file of the in-process validator")
+	output := flags.String("output", "", "file to write the JSON report to")
+	if err = flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New("usage: replay [flags] <capture file>...")
+	}
+
+	format, err := capture.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var replayTarget capture.Target
+	if *target != "" {
+		conn, err := grpc.NewClient(*target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		replayTarget = capture.NewClientTarget(conn)
+		if *token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+		}
+	} else {
+		opts, err := validatorOptions(cfg)
+		if err != nil {
+			return err
+		}
+		replayTarget, err = newServerTarget(*rules, opts...)
+		if err != nil {
+			return err
+		}
+	}
+
+	total := &capture.ReplayReport{Differences: []capture.Difference{}}
+	for _, path := range flags.Args() {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		report, err := capture.Replay(ctx, capture.NewReader(f, format), replayTarget)
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		total.Calls += report.Calls
+		total.Records += report.Records
+		total.Differences = append(total.Differences, report.Differences...)
+	}
+
+	if *output != "" {
+		data, err := json.MarshalIndent(total, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err = os.WriteFile(*output, data, 0o644); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("replayed %d calls, %d records, %d differences\n", total.Calls, total.Records, len(total.Differences))
+	if len(total.Differences) == 0 {
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tMETHOD\tKEY\tCAPTURED\tREPLAYED")
+	for _, d := range total.Differences {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", d.Time.UTC().Format(time.RFC3339), d.Method, d.Captured.Key, describeOutcome(d.Captured), describeOutcome(d.Replayed))
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%d results differ from the capture", len(total.Differences))
+}
+
+func describeOutcome(o capture.Outcome) string {
+	switch {
+	case o.Status != "":
+		return "status " + o.Status
+	case o.IsSuccess:
+		return "success"
+	default:
+		return fmt.Sprintf("error %d", o.ErrorCode)
+	}
+}
+
+// newServerTarget returns an in-process validator with the rule set of the
+// rulesPath file, or the built-in rules when rulesPath is empty.
+func newServerTarget(rulesPath string, opts ...server.Option) (capture.Target, error) {
+	validator := server.NewCloudsaveValidationServiceServer(opts...)
+	if rulesPath != "" {
+		config, err := server.LoadRuleSetConfig(rulesPath)
+		if err == nil {
+			err = validator.LoadRuleSet(config)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", rulesPath, err)
+		}
+	}
+
+	return capture.NewServerTarget(validator), nil
+}
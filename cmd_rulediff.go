@@ -0,0 +1,136 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/capture"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/config"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "rulediff",
+		summary: "compare the results of two rule sets over captured calls",
+		run:     runRuleDiff,
+	})
+}
+
+// runRuleDiff handles `rulediff [flags] <capture file>...`.
+func runRuleDiff(args []string) error {
+	cfg, err := config.Load(nil)
+	if err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet("rulediff", flag.ContinueOnError)
+	formatName := flags.String("format", string(capture.FormatProtobuf), "format of the capture files, protobuf or json")
+	basePath := flags.String("base", cfg.Rules.File, "rule set file deployed today, defaults to the built-in rules")
+	candidatePath := flags.String("candidate", "", "rule set file to deploy")
+	output := flags.String("output", "", "file to write the JSON report to")
+	failOnChange := flags.Bool("fail-on-change", false, "exit with status 1 when a result changed")
+	if err = flags.Parse(args); err != nil {
+		return err
+	}
+	if *candidatePath == "" || flags.NArg() == 0 {
+		return errors.New("usage: rulediff [-base file] -candidate file [flags] <capture file>...")
+	}
+
+	format, err := capture.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+	// both validators are configured as the server, only the rule sets differ
+	opts, err := validatorOptions(cfg)
+	if err != nil {
+		return err
+	}
+	base, err := newServerTarget(*basePath, opts...)
+	if err != nil {
+		return err
+	}
+	candidate, err := newServerTarget(*candidatePath, opts...)
+	if err != nil {
+		return err
+	}
+
+	total := &capture.RuleSetDiff{Changes: []capture.Change{}, Keys: map[string]*capture.KeyCounts{}}
+	for _, path := range flags.Args() {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		diff, err := capture.DiffRuleSets(context.Background(), capture.NewReader(f, format), base, candidate)
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		total.Calls += diff.Calls
+		total.Records += diff.Records
+		total.Changes = append(total.Changes, diff.Changes...)
+		for key, counts := range diff.Keys {
+			sum := total.Keys[key]
+			if sum == nil {
+				sum = &capture.KeyCounts{}
+				total.Keys[key] = sum
+			}
+			sum.Records += counts.Records
+			sum.BaseRejected += counts.BaseRejected
+			sum.CandidateRejected += counts.CandidateRejected
+			sum.Flipped += counts.Flipped
+			sum.ErrorCodeChanged += counts.ErrorCodeChanged
+			sum.StatusChanged += counts.StatusChanged
+		}
+	}
+
+	if *output != "" {
+		data, err := json.MarshalIndent(total, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err = os.WriteFile(*output, data, 0o644); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("evaluated %d calls, %d records, %d changed\n\n", total.Calls, total.Records, len(total.Changes))
+
+	keys := make([]string, 0, len(total.Keys))
+	for key := range total.Keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tRECORDS\tBASE REJECTED\tCANDIDATE REJECTED\tFLIPPED\tERROR CODE CHANGED\tSTATUS CHANGED")
+	for _, key := range keys {
+		c := total.Keys[key]
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\n", key, c.Records, c.BaseRejected, c.CandidateRejected, c.Flipped, c.ErrorCodeChanged, c.StatusChanged)
+	}
+	if len(total.Changes) > 0 {
+		fmt.Fprintln(w, "\nKEY\tMETHOD\tCHANGE\tBASE\tCANDIDATE")
+		for _, c := range total.Changes {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.Base.Key, c.Method, c.Kind, describeOutcome(c.Base), describeOutcome(c.Candidate))
+		}
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+
+	if *failOnChange && len(total.Changes) > 0 {
+		return fmt.Errorf("%d results changed", len(total.Changes))
+	}
+
+	return nil
+}
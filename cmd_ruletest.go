@@ -0,0 +1,78 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/config"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/ruletest"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "test",
+		summary: "run rule test case files",
+		run:     runTest,
+	})
+}
+
+// runTest handles `test [-v] <file or directory>...`. The validator is
+// configured as the app, e.g. messages are translated with the bundles of
+// LOCALES_DIR.
+func runTest(args []string) error {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	verbose := flags.Bool("v", false, "also list the cases that passed")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New("usage: test [-v] <file or directory>...")
+	}
+
+	cfg, err := config.Load(nil)
+	if err != nil {
+		return err
+	}
+	opts, err := validatorOptions(cfg)
+	if err != nil {
+		return err
+	}
+
+	suites, err := ruletest.LoadSuites(flags.Args()...)
+	if err != nil {
+		return err
+	}
+
+	passed, failed := 0, 0
+	for _, suite := range suites {
+		results, err := ruletest.RunSuite(context.Background(), suite, opts...)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			if result.Passed() {
+				passed++
+				if *verbose {
+					fmt.Printf("PASS %s: %s\n", suite.Path, result.Case.Name)
+				}
+
+				continue
+			}
+			failed++
+			fmt.Printf("FAIL %s: %s: %s\n", suite.Path, result.Case.Name, result.Failure)
+		}
+	}
+
+	fmt.Printf("%d passed, %d failed\n", passed, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d cases failed", failed)
+	}
+
+	return nil
+}
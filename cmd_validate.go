@@ -0,0 +1,226 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/capture"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/config"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/errorcode"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/server"
+)
+
+func init() {
+	registerCommand(command{
+		name:    "validate",
+		summary: "validate a single record offline",
+		run:     runValidate,
+	})
+}
+
+// recordKinds maps the record kinds to the suffix of their hooks.
+var recordKinds = map[string]string{
+	"game":          "GameRecord",
+	"player":        "PlayerRecord",
+	"admin-game":    "AdminGameRecord",
+	"admin-player":  "AdminPlayerRecord",
+	"game-binary":   "GameBinaryRecord",
+	"player-binary": "PlayerBinaryRecord",
+}
+
+// validateResult is the JSON output of the validate command.
+type validateResult struct {
+	Hook      string                   `json:"hook"`
+	Key       string                   `json:"key"`
+	Outcome   capture.Outcome          `json:"outcome"`
+	ErrorName string                   `json:"errorName,omitempty"`
+	Message   string                   `json:"message,omitempty"`
+	Report    *server.ValidationReport `json:"report,omitempty"`
+	Duration  string                   `json:"duration"`
+}
+
+// runValidate handles `validate -kind kind -key key [flags]`. The record is
+// sent to an in-process validator through the handlers of the gRPC server. It
+// fails when the record is not accepted.
+func runValidate(args []string) error {
+	cfg, err := config.Load(nil)
+	if err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	kind := flags.String("kind", "", "record kind: game, player, admin-game, admin-player, game-binary or player-binary")
+	hook := flags.String("hook", "", "hook to call, defaults to the BeforeWrite hook of the kind, e.g. AfterReadGameRecord")
+	key := flags.String("key", "", "record key")
+	namespace := flags.String("namespace", cfg.Auth.Namespace, "record namespace")
+	userID := flags.String("user-id", "", "user ID of a player record")
+	payloadPath := flags.String("payload", "", "JSON payload file, - reads the standard input")
+	binaryURL := flags.String("binary-url", "", "URL of the content of a binary record")
+	binaryPath := flags.String("binary-file", "", "file with the content of a binary record, served on a local URL")
+	binaryVersion := flags.Int("binary-version", 1, "version of a binary record")
+	rules := flags.String("rules", cfg.Rules.File, "rule set file, defaults to the built-in rules")
+	locale := flags.String("locale", "", "locale of the error messages")
+	jsonOutput := flags.Bool("json", false, "print the result as JSON")
+	if err = flags.Parse(args); err != nil {
+		return err
+	}
+
+	suffix, found := recordKinds[*kind]
+	if !found || *key == "" {
+		return errors.New("usage: validate -kind kind -key key [-payload file | -binary-url url | -binary-file file] [flags]")
+	}
+	if *hook == "" {
+		*hook = "BeforeWrite" + suffix
+	}
+
+	record := map[string]any{"key": *key, "namespace": *namespace, "createdAt": time.Now().UTC().Format(time.RFC3339)}
+	if strings.HasPrefix(*kind, "player") || *kind == "admin-player" {
+		record["userId"] = *userID
+	}
+	if strings.HasSuffix(*kind, "-binary") {
+		url := *binaryURL
+		if *binaryPath != "" {
+			content, err := os.ReadFile(*binaryPath)
+			if err != nil {
+				return err
+			}
+			binaryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.ServeContent(w, r, filepath.Base(*binaryPath), time.Time{}, bytes.NewReader(content))
+			}))
+			defer binaryServer.Close()
+			url = binaryServer.URL + "/" + filepath.Base(*binaryPath)
+		}
+		record["binaryInfo"] = map[string]any{"url": url, "version": *binaryVersion}
+	} else if *payloadPath != "" {
+		var payload []byte
+		var err error
+		if *payloadPath == "-" {
+			payload, err = io.ReadAll(os.Stdin)
+		} else {
+			payload, err = os.ReadFile(*payloadPath)
+		}
+		if err != nil {
+			return err
+		}
+		record["payload"] = base64.StdEncoding.EncodeToString(payload)
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	request, err := capture.NewRequest(*hook, data)
+	if err != nil {
+		return err
+	}
+
+	opts, err := validatorOptions(cfg)
+	if err != nil {
+		return err
+	}
+	target, err := newServerTarget(*rules, opts...)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if *locale != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(server.LocaleMetadataKey, *locale))
+	}
+	start := time.Now()
+	response, err := target.Invoke(ctx, "/"+*hook, request)
+	duration := time.Since(start)
+
+	result := validateResult{Hook: *hook, Key: *key, Duration: duration.String()}
+	result.Outcome = capture.Outcomes(request, response, err)[0]
+	if err != nil {
+		result.Message = status.Convert(err).Message()
+	} else {
+		result.Message = capture.ErrorMessage(response)
+	}
+	if entry, found := errorcode.Lookup(result.Outcome.ErrorCode); found && !result.Outcome.IsSuccess {
+		result.ErrorName = entry.Name
+	}
+	report := &server.ValidationReport{}
+	if json.Unmarshal([]byte(result.Message), report) == nil {
+		result.Report = report
+		result.Message = report.Message
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(result); err != nil {
+			return err
+		}
+
+		return validateError(result)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "hook:\t%s\n", result.Hook)
+	fmt.Fprintf(w, "key:\t%s\n", result.Key)
+	switch {
+	case result.Outcome.Status != "":
+		fmt.Fprintf(w, "result:\tcall failed with status %s\n", result.Outcome.Status)
+	case result.Outcome.IsSuccess:
+		fmt.Fprintf(w, "result:\taccepted\n")
+	default:
+		fmt.Fprintf(w, "result:\trejected, error code %d %s\n", result.Outcome.ErrorCode, result.ErrorName)
+	}
+	if result.Message != "" {
+		fmt.Fprintf(w, "message:\t%s\n", result.Message)
+	}
+	fmt.Fprintf(w, "time:\t%s\n", result.Duration)
+	if err = w.Flush(); err != nil {
+		return err
+	}
+
+	if result.Report != nil && len(result.Report.Violations) > 0 {
+		fmt.Println()
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "RULE\tFIELD\tCONSTRAINT\tCODE\tMESSAGE")
+		for _, v := range result.Report.Violations {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", v.Rule, v.Field, v.Constraint, v.Code, v.Message)
+		}
+		if result.Report.Omitted > 0 {
+			fmt.Fprintf(w, "\t\t\t\t(%d more)\n", result.Report.Omitted)
+		}
+		if err = w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return validateError(result)
+}
+
+func validateError(result validateResult) error {
+	switch {
+	case result.Outcome.Status != "":
+		return errors.New("validation failed")
+	case !result.Outcome.IsSuccess:
+		return errors.New("record rejected")
+	default:
+		return nil
+	}
+}
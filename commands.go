@@ -0,0 +1,72 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/config"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/i18n"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/server"
+)
+
+// command is a subcommand of the app binary. Without a subcommand the binary
+// runs the gRPC server.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands []command
+
+func registerCommand(c command) {
+	commands = append(commands, c)
+}
+
+func runCommand(name string, args []string) int {
+	for _, c := range commands {
+		if c.name == name {
+			if err := c.run(args); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", c.name, err)
+
+				return 1
+			}
+
+			return 0
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\nCommands:\n", name)
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", c.name, c.summary)
+	}
+
+	return 2
+}
+
+// validatorOptions returns the options of cfg deciding how a validator
+// evaluates records and reports their violations, so the commands evaluating
+// records in-process behave as the server.
+func validatorOptions(cfg *config.Config) ([]server.Option, error) {
+	reportFormat, err := server.ParseReportFormat(cfg.Report.Format)
+	if err != nil {
+		return nil, err
+	}
+	translator := i18n.NewTranslator(cfg.Locales.Default)
+	if dir := cfg.Locales.Dir; dir != "" {
+		if translator, err = i18n.LoadDir(dir, cfg.Locales.Default); err != nil {
+			return nil, err
+		}
+	}
+
+	return []server.Option{
+		server.WithReportFormat(reportFormat),
+		server.WithMaxReportedViolations(cfg.Report.MaxViolations),
+		server.WithTranslator(translator),
+		server.WithMaxEventBannerSize(cfg.Rules.MaxEventBannerKB),
+	}, nil
+}
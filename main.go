@@ -6,20 +6,31 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"log/slog"
 	"net"
 	"net/http"
-	_ "net/http/pprof"
 	"os"
 	"os/signal"
-	"runtime"
 	"strings"
 	"syscall"
 	"time"
 
+	"cloudsave-validator-grpc-plugin-server-go/pkg/admin"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/audit"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/capture"
 	"cloudsave-validator-grpc-plugin-server-go/pkg/common"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/config"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/debug"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/healthcheck"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/lifecycle"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/limiter"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/quarantine"
 	"cloudsave-validator-grpc-plugin-server-go/pkg/server"
 
 	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
@@ -30,6 +41,9 @@ import (
 	sdkAuth "github.com/AccelByte/accelbyte-go-sdk/services-api/pkg/utils/auth"
 	promgrpc "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/prometheus/client_golang/prometheus"
 	prometheusCollectors "github.com/prometheus/client_golang/prometheus/collectors"
 
@@ -40,22 +54,15 @@ import (
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	grpcCredentials "google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 )
 
 const (
-	environment     = "production"
-	id              = int64(1)
-	metricsEndpoint = "/metrics"
-	metricsPort     = 8080
-	grpcPort        = 6565
-)
-
-var (
-	serviceName = "extend-app-cloudsave-validator"
-	logLevelStr = common.GetEnv("LOG_LEVEL", "info")
+	defaultServiceName = "extend-app-cloudsave-validator"
 )
 
 func parseSlogLevel(levelStr string) slog.Level {
@@ -74,16 +81,29 @@ func parseSlogLevel(levelStr string) slog.Level {
 }
 
 func main() {
-	go func() {
-		runtime.SetBlockProfileRate(1)
-		runtime.SetMutexProfileFraction(10)
-	}()
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		os.Exit(runCommand(os.Args[1], os.Args[2:]))
+	}
+
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintf(os.Stderr, "Usage: %s [flags]\n       %s <command> [args]\n\nFlags:\n", os.Args[0], os.Args[0])
+		config.Usage(os.Stderr)
+		os.Exit(2)
+	}
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		os.Exit(2)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	// Parse log level from environment variable
-	slogLevel := parseSlogLevel(logLevelStr)
+	slogLevel := parseSlogLevel(cfg.LogLevel)
 
 	// Create JSON handler for structured logging
 	opts := &slog.HandlerOptions{
@@ -108,19 +128,39 @@ func main() {
 		logging.WithDurationField(logging.DurationToDurationField),
 	}
 
+	// Record payloads are redacted from the request and response logs
+	payloadRedactor := &common.PayloadRedactor{
+		Allowlist:       cfg.PayloadLogging.Allowlist,
+		MaxPayloadBytes: cfg.PayloadLogging.MaxBytes,
+		SampleRate:      cfg.PayloadLogging.SampleRate,
+	}
+	interceptorLogger := common.RedactingLogger(common.InterceptorLogger(logger), payloadRedactor)
+
+	// Calls are capped in time, then panics after the metrics and logs are
+	// recovered from, in the goroutine of the handler
+	methodTimeouts, _ := common.ParseMethodTimeouts(cfg.GRPC.MethodTimeouts)
+	recoveryOption := recovery.WithRecoveryHandlerContext(common.RecoverPanic)
+
 	srvMetrics := promgrpc.NewServerMetrics()
 	unaryServerInterceptors := []grpc.UnaryServerInterceptor{
 		srvMetrics.UnaryServerInterceptor(),
-		logging.UnaryServerInterceptor(common.InterceptorLogger(logger), loggingOptions...),
+		logging.UnaryServerInterceptor(interceptorLogger, loggingOptions...),
+		common.NewUnaryTimeoutServerInterceptor(time.Duration(cfg.GRPC.TimeoutSeconds)*time.Second, methodTimeouts),
+		recovery.UnaryServerInterceptor(recoveryOption),
 	}
 	streamServerInterceptors := []grpc.StreamServerInterceptor{
 		srvMetrics.StreamServerInterceptor(),
-		logging.StreamServerInterceptor(common.InterceptorLogger(logger), loggingOptions...),
+		logging.StreamServerInterceptor(interceptorLogger, loggingOptions...),
+		recovery.StreamServerInterceptor(recoveryOption),
 	}
 
 	// Preparing the IAM authorization
 	var tokenRepo repository.TokenRepository = sdkAuth.DefaultTokenRepositoryImpl()
-	var configRepo repository.ConfigRepository = sdkAuth.DefaultConfigRepositoryImpl()
+	var configRepo repository.ConfigRepository = &sdkAuth.ConfigRepositoryImpl{
+		ClientId:     cfg.Auth.ClientID,
+		ClientSecret: cfg.Auth.ClientSecret,
+		BaseUrl:      cfg.Auth.BaseURL,
+	}
 	var refreshRepo repository.RefreshTokenRepository = &sdkAuth.RefreshTokenImpl{RefreshRate: 0.8, AutoRefresh: true}
 
 	oauthService := iam.OAuth20Service{
@@ -130,12 +170,14 @@ func main() {
 		ConfigRepository:       configRepo,
 	}
 
-	if strings.ToLower(common.GetEnv("PLUGIN_GRPC_SERVER_AUTH_ENABLED", "true")) == "true" {
-		refreshInterval := common.GetEnvInt("REFRESH_INTERVAL", 600)
-		common.Validator = common.NewTokenValidator(oauthService, time.Duration(refreshInterval)*time.Second, true)
-		err := common.Validator.Initialize(ctx)
-		if err != nil {
-			logger.Info(err.Error())
+	common.Namespace = cfg.Auth.Namespace
+	var tokenValidatorMonitor *common.TokenValidatorMonitor
+	if cfg.Auth.Enabled {
+		refreshInterval := time.Duration(cfg.Auth.RefreshIntervalSeconds) * time.Second
+		common.Validator = common.NewTokenValidator(oauthService, refreshInterval, true)
+		tokenValidatorMonitor = common.NewTokenValidatorMonitor(common.Validator, oauthService, refreshInterval)
+		if err := tokenValidatorMonitor.Start(ctx); err != nil {
+			logger.Warn("failed to initialize token validator, retrying in the background", "error", err)
 		}
 
 		unaryServerInterceptors = append(unaryServerInterceptors, common.UnaryAuthServerIntercept)
@@ -143,23 +185,201 @@ func main() {
 		logger.Info("added auth interceptors")
 	}
 
+	// Capture the calls for replay
+	var capturer *capture.Capturer
+	if path := cfg.Capture.File; path != "" {
+		captureFormat, _ := capture.ParseFormat(cfg.Capture.Format)
+		captureFile, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			logger.Error("failed to open capture file", "path", path, "error", err)
+			os.Exit(1)
+		}
+		capturer = capture.NewCapturer(captureFile, captureFormat, capture.Config{
+			SampleRate:    cfg.Capture.SampleRate,
+			RedactUserIDs: cfg.Capture.RedactUserIDs,
+			MaxBytes:      int64(cfg.Capture.MaxSizeMB) * 1024 * 1024,
+			BufferSize:    cfg.Capture.BufferSize,
+		})
+		unaryServerInterceptors = append(unaryServerInterceptors, capturer.UnaryServerInterceptor())
+		logger.Info("capturing calls", "path", path, "format", captureFormat)
+	}
+
+	// Serve over TLS, with client certificates when a client CA is set
+	var tlsConfig *common.ReloadingTLSConfig
+	if cfg.TLS.CertFile != "" {
+		clientAuth := tls.RequireAndVerifyClientCert
+		if cfg.TLS.ClientAuth == "optional" {
+			clientAuth = tls.VerifyClientCertIfGiven
+		}
+		tlsConfig, err = common.NewReloadingTLSConfig(common.TLSFiles{
+			CertFile:     cfg.TLS.CertFile,
+			KeyFile:      cfg.TLS.KeyFile,
+			ClientCAFile: cfg.TLS.ClientCAFile,
+			ClientAuth:   clientAuth,
+		})
+		if err != nil {
+			logger.Error("failed to load TLS files", "error", err)
+			os.Exit(1)
+		}
+		go tlsConfig.Watch(ctx, time.Duration(cfg.TLS.ReloadIntervalSeconds)*time.Second)
+		common.PeerIdentities = cfg.TLS.AllowedIdentities
+		logger.Info("serving over TLS", "certFile", cfg.TLS.CertFile, "mutual", cfg.TLS.ClientCAFile != "")
+	}
+
+	// Register Filter Service
+	serverOptions, err := validatorOptions(cfg)
+	if err != nil {
+		logger.Error("failed to configure the validator", "error", err)
+		os.Exit(1)
+	}
+	if dir := cfg.Locales.Dir; dir != "" {
+		logger.Info("loaded locales", "dir", dir)
+	}
+	serverOptions = append(serverOptions, server.WithMaxKeyLabels(cfg.Metrics.MaxKeyLabels))
+	auditSink, err := newAuditSink(cfg.Audit)
+	if err != nil {
+		logger.Error("failed to create audit sink", "error", err)
+		os.Exit(1)
+	}
+	var auditLogger *audit.Logger
+	if auditSink != nil {
+		auditLogger = audit.NewLogger(auditSink, cfg.Audit.BufferSize)
+		serverOptions = append(serverOptions, server.WithAuditLogger(auditLogger))
+		logger.Info("audit log enabled", "sink", cfg.Audit.Sink)
+	}
+	recordQuarantine, err := newQuarantine(cfg.Quarantine)
+	if err != nil {
+		logger.Error("failed to create quarantine", "error", err)
+		os.Exit(1)
+	}
+	if recordQuarantine != nil {
+		recordQuarantine.Start()
+		serverOptions = append(serverOptions, server.WithQuarantine(recordQuarantine))
+		logger.Info("quarantine enabled", "store", cfg.Quarantine.Store)
+	}
+	cloudsaveValidatorServer := server.NewCloudsaveValidationServiceServer(serverOptions...)
+	if path := cfg.Rules.File; path != "" {
+		ruleSetConfig, err := server.LoadRuleSetConfig(path)
+		if err == nil {
+			err = cloudsaveValidatorServer.LoadRuleSet(ruleSetConfig)
+		}
+		if err != nil {
+			logger.Error("failed to load rule set", "path", path, "error", err)
+			os.Exit(1)
+		}
+		logger.Info("loaded rule set", "path", path, "version", ruleSetConfig.Version)
+	}
+	if cfg.Rules.ShadowMode {
+		cloudsaveValidatorServer.SetShadowMode(true)
+		logger.Warn("shadow mode enabled, rule failures will not reject records")
+	}
+
+	// Limit the calls in flight, innermost so the calls timed out but still
+	// running hold their slot
+	var concurrencyLimits *limiter.Set
+	if cfg.Concurrency.Enabled {
+		limiterConfig := func(maxLimit int) limiter.Config {
+			return limiter.Config{MinLimit: cfg.Concurrency.MinLimit, MaxLimit: maxLimit, LatencyTolerance: cfg.Concurrency.LatencyTolerance}
+		}
+		concurrencyLimits = limiter.NewSet(map[string]*limiter.Limiter{
+			server.MethodClassWrite: limiter.New(limiterConfig(cfg.Concurrency.WriteMaxLimit)),
+			server.MethodClassRead:  limiter.New(limiterConfig(cfg.Concurrency.ReadMaxLimit)),
+			server.MethodClassBulk:  limiter.New(limiterConfig(cfg.Concurrency.BulkMaxLimit)),
+		})
+		overloadPolicy, _ := server.ParseOverloadPolicy(cfg.Concurrency.OverloadPolicy)
+		overloaded := limiter.Rejected
+		if overloadPolicy != server.OverloadPolicyReject {
+			overloaded = func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo) (interface{}, error) {
+				return cloudsaveValidatorServer.OverloadedResponse(ctx, req, overloadPolicy)
+			}
+		}
+		unaryServerInterceptors = append(unaryServerInterceptors, concurrencyLimits.UnaryServerInterceptor(server.MethodClass, overloaded))
+		logger.Info("limiting calls in flight", "overloadPolicy", overloadPolicy)
+	}
+
 	// Create gRPC Server
-	grpcServer := grpc.NewServer(
+	grpcOptions := []grpc.ServerOption{
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.ChainUnaryInterceptor(unaryServerInterceptors...),
 		grpc.ChainStreamInterceptor(streamServerInterceptors...),
-	)
-
-	// Register Filter Service
-	cloudsaveValidatorServer := server.NewCloudsaveValidationServiceServer()
+		grpc.MaxRecvMsgSize(cfg.GRPC.MaxRecvMessageMB * 1024 * 1024),
+		grpc.MaxConcurrentStreams(uint32(cfg.GRPC.MaxConcurrentStreams)),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:             time.Duration(cfg.GRPC.KeepaliveTimeSeconds) * time.Second,
+			Timeout:          time.Duration(cfg.GRPC.KeepaliveTimeoutSeconds) * time.Second,
+			MaxConnectionAge: time.Duration(cfg.GRPC.MaxConnectionAgeSeconds) * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             time.Duration(cfg.GRPC.KeepaliveMinTimeSeconds) * time.Second,
+			PermitWithoutStream: true,
+		}),
+	}
+	if tlsConfig != nil {
+		grpcOptions = append(grpcOptions, grpc.Creds(grpcCredentials.NewTLS(tlsConfig.TLSConfig())))
+	}
+	grpcServer := grpc.NewServer(grpcOptions...)
 	pb.RegisterCloudsaveValidatorServiceServer(grpcServer, cloudsaveValidatorServer)
 
+	// Create the admin gRPC Server, on its own port so it is never exposed with the validator
+	var adminServer *grpc.Server
+	if cfg.Admin.Enabled {
+		// the config requires auth with the admin service, reflection included
+		permission := &iam.Permission{
+			Resource: cfg.Admin.PermissionResource,
+			Action:   cfg.Admin.PermissionAction,
+		}
+		adminOptions := []grpc.ServerOption{
+			grpc.StatsHandler(otelgrpc.NewServerHandler()),
+			grpc.ChainUnaryInterceptor(
+				logging.UnaryServerInterceptor(interceptorLogger, loggingOptions...),
+				recovery.UnaryServerInterceptor(recoveryOption),
+				common.NewUnaryAuthServerInterceptor(permission),
+			),
+			grpc.ChainStreamInterceptor(
+				logging.StreamServerInterceptor(interceptorLogger, loggingOptions...),
+				recovery.StreamServerInterceptor(recoveryOption),
+				common.NewStreamAuthServerInterceptor(permission),
+			),
+		}
+		// access tokens and evaluated records are never sent in plaintext
+		if tlsConfig != nil {
+			adminOptions = append(adminOptions, grpc.Creds(grpcCredentials.NewTLS(tlsConfig.TLSConfig())))
+		}
+		adminServer = grpc.NewServer(adminOptions...)
+		pb.RegisterCloudsaveValidatorAdminServiceServer(adminServer, admin.NewService(cloudsaveValidatorServer, cfg.Rules.File))
+		reflection.Register(adminServer)
+	}
+
 	// Enable gRPC Reflection
 	reflection.Register(grpcServer)
 	logger.Info("gRPC reflection enabled")
 
 	// Enable gRPC Health Check
-	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	healthChecker := healthcheck.NewChecker(healthServer,
+		time.Duration(cfg.Health.CheckIntervalSeconds)*time.Second,
+		pb.CloudsaveValidatorService_ServiceDesc.ServiceName,
+	)
+	if tokenValidatorMonitor != nil {
+		jwksMaxAge := 3 * time.Duration(cfg.Auth.RefreshIntervalSeconds) * time.Second
+		healthChecker.Add(healthcheck.Check{Name: "token validator", Critical: true, Run: tokenValidatorMonitor.CheckInitialized})
+		healthChecker.Add(healthcheck.Check{Name: "jwks", Critical: true, Run: tokenValidatorMonitor.CheckJWKS(jwksMaxAge)})
+	}
+	healthChecker.Add(healthcheck.Check{Name: "rule set", Critical: true, Run: cloudsaveValidatorServer.CheckRuleSet})
+	if tlsConfig != nil {
+		healthChecker.Add(healthcheck.Check{Name: "tls certificate", Critical: true, Run: tlsConfig.CheckCertificate})
+	}
+	if auditLogger != nil {
+		healthChecker.Add(healthcheck.Check{Name: "audit log buffer", Run: healthcheck.BufferCheck(auditLogger.Buffered, 0.9)})
+	}
+	if capturer != nil {
+		healthChecker.Add(healthcheck.Check{Name: "capture buffer", Run: healthcheck.BufferCheck(capturer.Buffered, 0.9)})
+	}
+	if recordQuarantine != nil {
+		healthChecker.Add(healthcheck.Check{Name: "quarantine buffer", Run: healthcheck.BufferCheck(recordQuarantine.Buffered, 0.9)})
+	}
+	healthChecker.Start(ctx)
 
 	// Register Prometheus Metrics
 	srvMetrics.InitializeMetrics(grpcServer)
@@ -168,31 +388,66 @@ func main() {
 		prometheusCollectors.NewGoCollector(),
 		prometheusCollectors.NewProcessCollector(prometheusCollectors.ProcessCollectorOpts{}),
 		srvMetrics,
+		cloudsaveValidatorServer.Metrics(),
+		common.RecoveredPanics,
 	)
+	if concurrencyLimits != nil {
+		prometheusRegistry.MustRegister(concurrencyLimits)
+	}
 
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle(cfg.Metrics.Endpoint, promhttp.HandlerFor(prometheusRegistry, promhttp.HandlerOpts{}))
+	metricsMux.Handle("/healthz", healthChecker.LivenessHandler())
+	metricsMux.Handle("/readyz", healthChecker.ReadinessHandler())
+	metricsServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Metrics.Port),
+		Handler:           metricsMux,
+		ReadHeaderTimeout: time.Duration(cfg.Metrics.ReadTimeoutSeconds) * time.Second,
+		ReadTimeout:       time.Duration(cfg.Metrics.ReadTimeoutSeconds) * time.Second,
+		WriteTimeout:      time.Duration(cfg.Metrics.WriteTimeoutSeconds) * time.Second,
+		IdleTimeout:       time.Duration(cfg.Metrics.IdleTimeoutSeconds) * time.Second,
+	}
 	go func() {
-		http.Handle(metricsEndpoint, promhttp.HandlerFor(prometheusRegistry, promhttp.HandlerOpts{}))
-		log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", metricsPort), nil))
+		if err := metricsServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
 	}()
-	logger.Info("serving prometheus metrics", "port", metricsPort, "endpoint", metricsEndpoint)
+	logger.Info("serving prometheus metrics", "port", cfg.Metrics.Port, "endpoint", cfg.Metrics.Endpoint)
+
+	// Serve pprof apart from the metrics, without write timeout for the
+	// profiles and traces taking longer
+	var debugServer *http.Server
+	if cfg.Debug.Enabled {
+		debugServer = &http.Server{
+			Addr: cfg.Debug.Address,
+			Handler: debug.NewHandler(debug.Config{
+				Username:             cfg.Debug.Username,
+				Password:             cfg.Debug.Password,
+				BlockProfileRate:     cfg.Debug.BlockProfileRate,
+				MutexProfileFraction: cfg.Debug.MutexProfileFraction,
+			}),
+			ReadHeaderTimeout: time.Duration(cfg.Metrics.ReadTimeoutSeconds) * time.Second,
+		}
+		go func() {
+			if err := debugServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				log.Fatal(err)
+			}
+		}()
+		logger.Info("serving pprof", "address", cfg.Debug.Address, "basicAuth", cfg.Debug.Username != "")
+	}
 
 	// Set Tracer Provider
-	if val := common.GetEnv("OTEL_SERVICE_NAME", ""); val != "" {
-		serviceName = "extend-app-cv-" + strings.ToLower(val)
+	serviceName := defaultServiceName
+	if cfg.ServiceName != "" {
+		serviceName = "extend-app-cv-" + strings.ToLower(cfg.ServiceName)
 	}
-	tracerProvider, err := common.NewTracerProvider(serviceName, environment, id)
+	tracerProvider, err := common.NewTracerProvider(serviceName, cfg.Environment, cfg.ServiceID)
 	if err != nil {
 		logger.Error("failed to create tracer provider", "error", err)
 		os.Exit(1)
 	}
 	otel.SetTracerProvider(tracerProvider)
-	defer func(ctx context.Context) {
-		if err := tracerProvider.Shutdown(ctx); err != nil {
-			logger.Error("tracer provider shutdown failed", "error", err)
-			os.Exit(1)
-		}
-	}(ctx)
-	logger.Info("set tracer provider", "name", serviceName, "environment", environment, "id", id)
+	logger.Info("set tracer provider", "name", serviceName, "environment", cfg.Environment, "id", cfg.ServiceID)
 
 	// Set Text Map Propagator
 	b := b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader))
@@ -207,9 +462,9 @@ func main() {
 
 	// Start gRPC Server
 	logger.Info("starting gRPC server..")
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", grpcPort))
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPC.Port))
 	if err != nil {
-		logger.Error("failed to listen to tcp", "port", grpcPort, "error", err)
+		logger.Error("failed to listen to tcp", "port", cfg.GRPC.Port, "error", err)
 		os.Exit(1)
 	}
 	go func() {
@@ -219,10 +474,122 @@ func main() {
 		}
 	}()
 	logger.Info("gRPC server started")
+	if adminServer != nil {
+		port := cfg.Admin.Port
+		adminLis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+		if err != nil {
+			logger.Error("failed to listen to tcp", "port", port, "error", err)
+			os.Exit(1)
+		}
+		go func() {
+			if err := adminServer.Serve(adminLis); err != nil {
+				logger.Error("failed to run admin gRPC server", "error", err)
+				os.Exit(1)
+			}
+		}()
+		logger.Info("admin gRPC server started", "port", port)
+	}
 	logger.Info("app server started")
 
 	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
 	defer stop()
 	<-ctx.Done()
 	logger.Info("signal received")
+
+	// Stop taking new calls, let the calls in flight finish, then flush
+	shutdown := lifecycle.NewManager(logger)
+	shutdown.OnShutdown("mark not serving", 0, func(context.Context) error {
+		healthChecker.Shutdown()
+
+		return nil
+	})
+	shutdown.OnShutdown("drain", 0, lifecycle.Wait(time.Duration(cfg.Shutdown.DrainSeconds)*time.Second))
+	stopTimeout := time.Duration(cfg.Shutdown.TimeoutSeconds) * time.Second
+	shutdown.OnShutdown("stop gRPC server", stopTimeout, lifecycle.StopGRPCServer(grpcServer))
+	if adminServer != nil {
+		shutdown.OnShutdown("stop admin gRPC server", stopTimeout, lifecycle.StopGRPCServer(adminServer))
+	}
+	shutdown.OnShutdown("stop metrics server", 5*time.Second, lifecycle.StopHTTPServer(metricsServer))
+	if debugServer != nil {
+		shutdown.OnShutdown("stop debug server", 5*time.Second, lifecycle.StopHTTPServer(debugServer))
+	}
+	if auditLogger != nil {
+		shutdown.OnShutdown("flush audit log", 5*time.Second, auditLogger.Close)
+	}
+	if capturer != nil {
+		shutdown.OnShutdown("flush capture file", 5*time.Second, capturer.Close)
+	}
+	if recordQuarantine != nil {
+		shutdown.OnShutdown("flush quarantine", 5*time.Second, recordQuarantine.Close)
+	}
+	shutdown.OnShutdown("flush traces", 5*time.Second, tracerProvider.Shutdown)
+	if err := shutdown.Shutdown(); err != nil {
+		os.Exit(1)
+	}
+	logger.Info("app server stopped")
+}
+
+// newAuditSink returns nil for the none sink.
+func newAuditSink(cfg config.AuditConfig) (audit.Sink, error) {
+	switch strings.ToLower(cfg.Sink) {
+	case "none", "":
+		return nil, nil
+	case "stdout":
+		return audit.NewStdoutSink(), nil
+	case "file":
+		return audit.NewFileSink(cfg.FilePath, int64(cfg.FileMaxSizeMB)*1024*1024, cfg.FileMaxBackups)
+	case "webhook":
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("AUDIT_WEBHOOK_URL is required by the webhook audit sink")
+		}
+
+		return audit.NewWebhookSink(cfg.WebhookURL, time.Duration(cfg.WebhookTimeoutSeconds)*time.Second), nil
+	default:
+		return nil, fmt.Errorf("unsupported audit sink %q", cfg.Sink)
+	}
+}
+
+// newQuarantine returns nil when the store is none. The quarantine is also
+// used by the quarantine command, so it is not started.
+func newQuarantine(cfg config.QuarantineConfig) (*quarantine.Quarantine, error) {
+	var store quarantine.Store
+	switch name := strings.ToLower(cfg.Store); name {
+	case "none", "":
+		return nil, nil
+	case "dir":
+		dirStore, err := quarantine.NewDirStore(cfg.Dir)
+		if err != nil {
+			return nil, err
+		}
+		store = dirStore
+	case "s3":
+		client, err := minio.New(cfg.S3.Endpoint, &minio.Options{
+			Creds:  credentials.NewStaticV4(cfg.S3.AccessKey, cfg.S3.SecretKey, ""),
+			Secure: cfg.S3.UseSSL,
+			Region: cfg.S3.Region,
+		})
+		if err != nil {
+			return nil, err
+		}
+		store = quarantine.NewS3Store(client, cfg.S3.Bucket, cfg.S3.Prefix)
+	default:
+		return nil, fmt.Errorf("unsupported quarantine store %q", name)
+	}
+
+	var encryptionKey []byte
+	if cfg.EncryptionKey != "" {
+		var err error
+		if encryptionKey, err = base64.StdEncoding.DecodeString(cfg.EncryptionKey); err != nil {
+			return nil, fmt.Errorf("QUARANTINE_ENCRYPTION_KEY is not base64: %w", err)
+		}
+	}
+
+	return quarantine.New(store, quarantine.Config{
+		Retention:       time.Duration(cfg.RetentionHours) * time.Hour,
+		MaxPayloadBytes: cfg.MaxPayloadKB * 1024,
+		MaxTotalBytes:   int64(cfg.MaxTotalMB) * 1024 * 1024,
+		EncryptionKey:   encryptionKey,
+		BufferSize:      cfg.BufferSize,
+		PruneInterval:   time.Minute,
+	})
 }
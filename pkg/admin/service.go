@@ -0,0 +1,211 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+// Package admin implements CloudsaveValidatorAdminService, used by operators
+// to inspect and change a running validator.
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/capture"
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/server"
+)
+
+// modeDisabled is the mode reported for, and used to turn off, disabled rules.
+const modeDisabled = "disabled"
+
+type Service struct {
+	pb.UnimplementedCloudsaveValidatorAdminServiceServer
+
+	validator   *server.CloudsaveValidatorServer
+	ruleSetFile string
+}
+
+// NewService returns the admin service of validator. ruleSetFile is the rule
+// set file ReloadRuleSet reads, reloading is refused when it is empty.
+func NewService(validator *server.CloudsaveValidatorServer, ruleSetFile string) *Service {
+	return &Service{validator: validator, ruleSetFile: ruleSetFile}
+}
+
+func (s *Service) ListRules(_ context.Context, _ *pb.ListRulesRequest) (*pb.ListRulesResponse, error) {
+	ruleSet := s.validator.RuleSet()
+
+	response := &pb.ListRulesResponse{RuleSetVersion: ruleSet.Version}
+	for _, rule := range ruleSet.Rules {
+		response.Rules = append(response.Rules, newRule(rule, string(rule.Mode)))
+	}
+	for _, rule := range ruleSet.Disabled {
+		response.Rules = append(response.Rules, newRule(rule, modeDisabled))
+	}
+
+	return response, nil
+}
+
+func (s *Service) GetConfig(_ context.Context, _ *pb.GetConfigRequest) (*pb.GetConfigResponse, error) {
+	ruleSet := s.validator.RuleSet()
+	config, err := json.Marshal(ruleSet.Config())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.GetConfigResponse{
+		RuleSetVersion: ruleSet.Version,
+		ConfigHash:     ruleSet.Hash(),
+		RuleSetConfig:  string(config),
+		RuleSetFile:    s.ruleSetFile,
+		ShadowMode:     s.validator.ShadowMode(),
+	}, nil
+}
+
+// Evaluate calls the validator in-process, through the same handlers as the
+// gRPC server, and explains the result of every rule.
+func (s *Service) Evaluate(ctx context.Context, request *pb.EvaluateRequest) (*pb.EvaluateResponse, error) {
+	hookRequest, err := capture.NewRequest(request.GetHook(), []byte(request.GetRecordJson()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// only admins may change the time the rules see
+	ctx, err = server.ContextWithNowFromMetadata(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// the caller's metadata is meant for the admin service, not the validator
+	md := metadata.MD{}
+	if request.GetLocale() != "" {
+		md.Set(server.LocaleMetadataKey, request.GetLocale())
+	}
+	ctx, explanation := server.ContextWithExplanation(metadata.NewIncomingContext(ctx, md))
+
+	hookResponse, err := capture.NewServerTarget(s.validator).Invoke(ctx, "/"+request.GetHook(), hookRequest)
+
+	response := &pb.EvaluateResponse{}
+	if err != nil {
+		st := status.Convert(err)
+		response.StatusCode = int32(st.Code())
+		response.StatusMessage = st.Message()
+	}
+
+	traces := explanation.Records()
+	for i, outcome := range capture.Outcomes(hookRequest, hookResponse, err) {
+		record := &pb.RecordEvaluation{
+			Key:       outcome.Key,
+			UserId:    outcome.UserID,
+			IsSuccess: outcome.IsSuccess,
+			ErrorCode: outcome.ErrorCode,
+		}
+		if err == nil && !outcome.IsSuccess {
+			record.ErrorMessage = capture.ErrorMessage(hookResponse)
+		}
+		if i < len(traces) {
+			for _, rule := range traces[i].Rules {
+				record.Rules = append(record.Rules, newRuleEvaluation(rule))
+			}
+		}
+		response.Records = append(response.Records, record)
+	}
+
+	return response, nil
+}
+
+func (s *Service) SetRuleMode(ctx context.Context, request *pb.SetRuleModeRequest) (*pb.SetRuleModeResponse, error) {
+	var mode server.Mode
+	disabled := strings.EqualFold(request.GetMode(), modeDisabled)
+	if !disabled {
+		var err error
+		if mode, err = server.ParseMode(request.GetMode()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if err := s.validator.SetRuleMode(request.GetRule(), mode, disabled); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	slog.InfoContext(ctx, "rule mode changed", "rule", request.GetRule(), "mode", request.GetMode())
+
+	ruleSet := s.validator.RuleSet()
+	response := &pb.SetRuleModeResponse{ConfigHash: ruleSet.Hash()}
+	for _, rule := range ruleSet.Rules {
+		if rule.Name == request.GetRule() {
+			response.Rule = newRule(rule, string(rule.Mode))
+		}
+	}
+	for _, rule := range ruleSet.Disabled {
+		if rule.Name == request.GetRule() {
+			response.Rule = newRule(rule, modeDisabled)
+		}
+	}
+
+	return response, nil
+}
+
+func (s *Service) SetShadowMode(ctx context.Context, request *pb.SetShadowModeRequest) (*pb.SetShadowModeResponse, error) {
+	s.validator.SetShadowMode(request.GetEnabled())
+	slog.InfoContext(ctx, "shadow mode changed", "enabled", request.GetEnabled())
+
+	return &pb.SetShadowModeResponse{Enabled: s.validator.ShadowMode()}, nil
+}
+
+// ReloadRuleSet reads the rule set file again, discarding the rule modes set
+// since the last load.
+func (s *Service) ReloadRuleSet(ctx context.Context, _ *pb.ReloadRuleSetRequest) (*pb.ReloadRuleSetResponse, error) {
+	if s.ruleSetFile == "" {
+		return nil, status.Error(codes.FailedPrecondition, "no rule set file configured")
+	}
+
+	config, err := server.LoadRuleSetConfig(s.ruleSetFile)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err = s.validator.LoadRuleSet(config); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	ruleSet := s.validator.RuleSet()
+	slog.InfoContext(ctx, "rule set reloaded", "file", s.ruleSetFile, "version", ruleSet.Version, "configHash", ruleSet.Hash())
+
+	return &pb.ReloadRuleSetResponse{RuleSetVersion: ruleSet.Version, ConfigHash: ruleSet.Hash()}, nil
+}
+
+func newRule(rule *server.Rule, mode string) *pb.Rule {
+	hooks := make([]string, 0, len(rule.Hooks))
+	for _, hook := range rule.Hooks {
+		hooks = append(hooks, string(hook))
+	}
+
+	return &pb.Rule{
+		Name:       rule.Name,
+		Version:    rule.Version,
+		KeyPattern: rule.KeyPattern,
+		Hooks:      hooks,
+		Mode:       mode,
+	}
+}
+
+func newRuleEvaluation(rule server.RuleTrace) *pb.RuleEvaluation {
+	return &pb.RuleEvaluation{
+		Rule:        rule.Rule,
+		Version:     rule.Version,
+		Mode:        string(rule.Mode),
+		Shadow:      rule.Shadow,
+		Outcome:     rule.Outcome,
+		Duration:    durationpb.New(rule.Duration),
+		ErrorCode:   int32(rule.ErrorCode),
+		ErrorName:   rule.ErrorName,
+		Message:     rule.Message,
+		Error:       rule.Error,
+		ErrorPolicy: string(rule.ErrorPolicy),
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/audit"
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/server"
+)
+
+// series returns the number of series of the validator metrics.
+func series(t *testing.T, validator *server.CloudsaveValidatorServer) int {
+	t.Helper()
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(validator.Metrics())
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := 0
+	for _, family := range families {
+		n += len(family.GetMetric())
+	}
+
+	return n
+}
+
+func TestEvaluate(t *testing.T) {
+	var events bytes.Buffer
+	auditLogger := audit.NewLogger(audit.NewWriterSink(&events), 10)
+	validator := server.NewCloudsaveValidationServiceServer(server.WithAuditLogger(auditLogger))
+	service := NewService(validator, "")
+
+	payload := []byte(`{"name":""}`)
+	response, err := service.Evaluate(context.Background(), &pb.EvaluateRequest{
+		Hook:       "BeforeWriteGameRecord",
+		RecordJson: fmt.Sprintf(`{"key":"town_map","namespace":"mygame","payload":%q}`, base64.StdEncoding.EncodeToString(payload)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.GetRecords()) != 1 {
+		t.Fatalf("expected 1 record, got %v", response.GetRecords())
+	}
+	record := response.GetRecords()[0]
+	if record.GetKey() != "town_map" || record.GetIsSuccess() || record.GetErrorCode() == 0 {
+		t.Errorf("unexpected result %v", record)
+	}
+	var explained bool
+	for _, rule := range record.GetRules() {
+		if rule.GetRule() == "map_schema" {
+			explained = rule.GetOutcome() == "fail" && rule.GetErrorCode() == record.GetErrorCode() && rule.GetMessage() != ""
+		}
+	}
+	if !explained {
+		t.Errorf("the failure of map_schema is not explained: %v", record.GetRules())
+	}
+	if n := series(t, validator); n != 0 {
+		t.Errorf("the evaluation recorded %d series", n)
+	}
+
+	// the same record sent by CloudSave is observed and audited
+	if _, err = validator.BeforeWriteGameRecord(context.Background(), &pb.GameRecord{Key: "town_map", Namespace: "mygame", Payload: payload}); err != nil {
+		t.Fatal(err)
+	}
+	if n := series(t, validator); n == 0 {
+		t.Error("the validator recorded no series")
+	}
+	if err = auditLogger.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if lines := bytes.Count(events.Bytes(), []byte("\n")); lines != 1 {
+		t.Errorf("audited %d events, want only the one of the validator call:\n%s", lines, events.String())
+	}
+}
+
+func TestEvaluateRejectsInvalidRecords(t *testing.T) {
+	service := NewService(server.NewCloudsaveValidationServiceServer(), "")
+
+	for _, request := range []*pb.EvaluateRequest{
+		{Hook: "BeforeWriteGameRecord", RecordJson: `{"key":`},
+		{Hook: "BeforeDeleteGameRecord", RecordJson: `{"key":"town_map"}`},
+	} {
+		if _, err := service.Evaluate(context.Background(), request); err == nil {
+			t.Errorf("evaluated %v", request)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+// Package audit records the records rejected by the validator to a durable
+// sink, so a rejected save can be traced back to the rule that rejected it.
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Event is the audit entry of a record rejected by a rule.
+type Event struct {
+	Time        time.Time `json:"time"`
+	Namespace   string    `json:"namespace"`
+	UserID      string    `json:"userId,omitempty"`
+	Key         string    `json:"key"`
+	Hook        string    `json:"hook"`
+	Rule        string    `json:"rule"`
+	ErrorCode   int32     `json:"errorCode"`
+	ErrorName   string    `json:"errorName"`
+	PayloadHash string    `json:"payloadHash,omitempty"`
+	ClientID    string    `json:"clientId,omitempty"`
+}
+
+// Sink writes audit events to durable storage.
+type Sink interface {
+	Write(ctx context.Context, events []Event) error
+	Close() error
+}
+
+// maxBatchSize is the maximum number of events passed to a single Sink.Write.
+const maxBatchSize = 100
+
+// Logger buffers audit events and writes them to its sink in the background,
+// so recording an event never blocks the caller.
+type Logger struct {
+	sink    Sink
+	dropped atomic.Uint64
+
+	mu     sync.RWMutex
+	closed bool
+	events chan Event
+	done   chan struct{}
+}
+
+// NewLogger starts a Logger buffering up to bufferSize events. Events
+// recorded while the buffer is full are dropped.
+func NewLogger(sink Sink, bufferSize int) *Logger {
+	l := &Logger{
+		sink:   sink,
+		events: make(chan Event, bufferSize),
+		done:   make(chan struct{}),
+	}
+	go l.run()
+
+	return l
+}
+
+// Record queues event and returns false when the buffer is full, or the
+// logger closed, and the event was dropped.
+func (l *Logger) Record(event Event) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.closed {
+		l.dropped.Add(1)
+
+		return false
+	}
+	select {
+	case l.events <- event:
+		return true
+	default:
+		l.dropped.Add(1)
+
+		return false
+	}
+}
+
+// Buffered returns the number of events waiting to be written and the size
+// of the buffer.
+func (l *Logger) Buffered() (int, int) {
+	return len(l.events), cap(l.events)
+}
+
+// Dropped returns the number of events dropped because the buffer was full.
+func (l *Logger) Dropped() uint64 {
+	return l.dropped.Load()
+}
+
+// Close writes the buffered events and closes the sink. Events recorded
+// after Close are dropped.
+func (l *Logger) Close(ctx context.Context) error {
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.events)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-l.done:
+		return l.sink.Close()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *Logger) run() {
+	defer close(l.done)
+
+	batch := make([]Event, 0, maxBatchSize)
+	for event := range l.events {
+		batch = append(batch, event)
+		// Take whatever else is already buffered, without waiting for more
+	fill:
+		for len(batch) < maxBatchSize {
+			select {
+			case next, ok := <-l.events:
+				if !ok {
+					break fill
+				}
+				batch = append(batch, next)
+			default:
+				break fill
+			}
+		}
+
+		if err := l.sink.Write(context.Background(), batch); err != nil {
+			slog.Error("failed to write audit events", "count", len(batch), "error", err)
+		}
+		batch = batch[:0]
+	}
+}
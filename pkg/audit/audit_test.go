@@ -0,0 +1,237 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func event(i int) Event {
+	return Event{
+		Time:      time.Date(2024, 3, 1, 0, 0, i, 0, time.UTC),
+		Namespace: "mygame",
+		Key:       fmt.Sprintf("key_%d", i),
+		Hook:      "BeforeWriteGameRecord",
+		Rule:      "map_schema",
+		ErrorCode: 1,
+		ErrorName: "SCHEMA_INVALID",
+	}
+}
+
+// memorySink keeps the batches written. Write signals writing, then waits
+// for release to be closed.
+type memorySink struct {
+	writing chan struct{}
+	release chan struct{}
+
+	mu      sync.Mutex
+	batches [][]Event
+	closed  bool
+}
+
+func (s *memorySink) Write(_ context.Context, events []Event) error {
+	select {
+	case s.writing <- struct{}{}:
+	default:
+	}
+	<-s.release
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, append([]Event(nil), events...))
+
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+
+	return nil
+}
+
+func TestLogger(t *testing.T) {
+	sink := &memorySink{writing: make(chan struct{}, 1), release: make(chan struct{})}
+	l := NewLogger(sink, 2*maxBatchSize)
+
+	// the first event is taken by the blocked Write, the others fill the
+	// buffer
+	if !l.Record(event(0)) {
+		t.Fatal("first event dropped")
+	}
+	<-sink.writing
+	for i := 1; i <= 2*maxBatchSize; i++ {
+		if !l.Record(event(i)) {
+			t.Fatalf("event %d dropped with room in the buffer", i)
+		}
+	}
+	if l.Record(event(-1)) || l.Dropped() != 1 {
+		t.Errorf("event recorded into a full buffer, %d dropped", l.Dropped())
+	}
+
+	close(sink.release)
+	if err := l.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if l.Record(event(-1)) || l.Dropped() != 2 {
+		t.Errorf("event recorded after Close, %d dropped", l.Dropped())
+	}
+
+	var written int
+	for _, batch := range sink.batches {
+		if len(batch) > maxBatchSize {
+			t.Errorf("batch of %d events, more than %d", len(batch), maxBatchSize)
+		}
+		for _, e := range batch {
+			if e.Key != event(written).Key {
+				t.Fatalf("event %d is %s, want %s", written, e.Key, event(written).Key)
+			}
+			written++
+		}
+	}
+	if written != 2*maxBatchSize+1 || !sink.closed {
+		t.Errorf("wrote %d events, closed %v", written, sink.closed)
+	}
+}
+
+func readLines(t *testing.T, path string) []Event {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var e Event
+		if err = json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		events = append(events, e)
+	}
+
+	return events
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "audit.log")
+	line, err := json.Marshal(event(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// two events per file
+	maxBytes := int64(2*(len(line)+1) + 1)
+
+	sink, err := NewFileSink(path, maxBytes, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		if err = sink.Write(context.Background(), []Event{event(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a reopened file keeps counting its size, and the oldest backup is
+	// deleted
+	if sink, err = NewFileSink(path, maxBytes, 2); err != nil {
+		t.Fatal(err)
+	}
+	for i := 5; i < 7; i++ {
+		if err = sink.Write(context.Background(), []Event{event(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string][]string{
+		path:        {"key_6"},
+		path + ".1": {"key_4", "key_5"},
+		path + ".2": {"key_2", "key_3"},
+	} {
+		events := readLines(t, name)
+		if len(events) != len(want) {
+			t.Fatalf("%s has %d events, want %d", name, len(events), len(want))
+		}
+		for i, e := range events {
+			if e.Key != want[i] {
+				t.Errorf("%s: event %d is %s, want %s", name, i, e.Key, want[i])
+			}
+		}
+	}
+	if _, err = os.Stat(path + ".3"); err == nil {
+		t.Error("kept more than 2 backups")
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var received []Event
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected %s request of %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		var body struct {
+			Events []Event `json:"events"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		received = append(received, body.Events...)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, time.Second)
+	defer sink.Close()
+	if err := sink.Write(context.Background(), []Event{event(0), event(1)}); err != nil {
+		t.Fatal(err)
+	}
+	if len(received) != 2 || received[1].Key != "key_1" {
+		t.Errorf("received %+v", received)
+	}
+
+	status = http.StatusServiceUnavailable
+	if err := sink.Write(context.Background(), []Event{event(2)}); err == nil {
+		t.Error("expected an error on status 503")
+	}
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewWriterSink(&buf)
+	if err := sink.Write(context.Background(), []Event{event(0), event(1)}); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 JSON lines, got %q", buf.String())
+	}
+	var e Event
+	if err := json.Unmarshal(lines[1], &e); err != nil || e.Key != "key_1" {
+		t.Errorf("unexpected line %s: %v", lines[1], err)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+)
+
+// WriterSink writes events to an io.Writer as JSON lines.
+type WriterSink struct {
+	w io.Writer
+}
+
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+// NewStdoutSink writes events to the standard output, next to the service
+// logs.
+func NewStdoutSink() *WriterSink {
+	return NewWriterSink(os.Stdout)
+}
+
+func (s *WriterSink) Write(_ context.Context, events []Event) error {
+	encoder := json.NewEncoder(s.w)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *WriterSink) Close() error {
+	return nil
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+// Package capture records the calls received by the validator to capture
+// files, and replays them against a validator to compare the results.
+package capture
+
+import (
+	"context"
+	"crypto/hmac"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+)
+
+// Config configures a Capturer.
+type Config struct {
+	// SampleRate is the fraction, between 0 and 1, of the calls captured.
+	SampleRate float64
+	// RedactUserIDs replaces user IDs with a pseudonym, the same user ID
+	// always gets the same pseudonym in a capture.
+	RedactUserIDs bool
+	// MaxBytes stops capturing once the capture file has reached this size,
+	// counting what it held when the Capturer was created, 0 means no limit.
+	MaxBytes int64
+	// BufferSize is the maximum number of calls waiting to be written, calls
+	// are dropped when the buffer is full.
+	BufferSize int
+}
+
+// Capturer records the calls to CloudsaveValidatorService. Calls are written
+// in the background, so capturing does not add latency to the calls.
+type Capturer struct {
+	config Config
+	writer *Writer
+	closer io.Closer
+	secret []byte
+
+	mu     sync.RWMutex
+	closed bool
+	calls  chan *pb.CapturedCall
+	done   chan struct{}
+}
+
+// NewCapturer writes the captured calls to w in format, and closes w when
+// it is an io.Closer. When w is a file, the calls are appended to it.
+func NewCapturer(w io.Writer, format Format, config Config) *Capturer {
+	c := &Capturer{
+		config: config,
+		writer: NewWriter(w, format),
+		secret: make([]byte, 32),
+		calls:  make(chan *pb.CapturedCall, config.BufferSize),
+		done:   make(chan struct{}),
+	}
+	c.closer, _ = w.(io.Closer)
+	// the file may hold the calls captured before a restart
+	if file, ok := w.(interface{ Stat() (fs.FileInfo, error) }); ok {
+		if info, err := file.Stat(); err == nil && info.Mode().IsRegular() {
+			c.writer.written = info.Size()
+		}
+	}
+	_, _ = cryptorand.Read(c.secret)
+	go c.run()
+
+	return c
+}
+
+// UnaryServerInterceptor captures the calls to the CloudsaveValidatorService
+// methods.
+func (c *Capturer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	prefix := "/" + pb.CloudsaveValidatorService_ServiceDesc.ServiceName + "/"
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		if strings.HasPrefix(info.FullMethod, prefix) && c.sampled() {
+			c.capture(start, info.FullMethod, req, resp, err)
+		}
+
+		return resp, err
+	}
+}
+
+// Buffered returns the number of calls waiting to be written and the size of
+// the buffer.
+func (c *Capturer) Buffered() (int, int) {
+	return len(c.calls), cap(c.calls)
+}
+
+// Close writes the buffered calls and closes the capture file. Calls received
+// after Close are not captured.
+func (c *Capturer) Close(ctx context.Context) error {
+	c.mu.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.calls)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-c.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if c.closer != nil {
+		return c.closer.Close()
+	}
+
+	return nil
+}
+
+func (c *Capturer) sampled() bool {
+	return c.config.SampleRate >= 1 || rand.Float64() < c.config.SampleRate
+}
+
+func (c *Capturer) capture(start time.Time, method string, req any, resp any, err error) {
+	call := &pb.CapturedCall{
+		Time:          timestamppb.New(start),
+		Method:        method,
+		StatusCode:    int32(status.Code(err)),
+		StatusMessage: status.Convert(err).Message(),
+	}
+
+	var marshalErr error
+	if message, ok := req.(proto.Message); ok {
+		call.Request, marshalErr = anypb.New(c.redact(message))
+	}
+	if message, ok := resp.(proto.Message); ok && err == nil && marshalErr == nil {
+		call.Response, marshalErr = anypb.New(c.redact(message))
+	}
+	if marshalErr != nil {
+		slog.Warn("failed to capture call", "method", method, "error", marshalErr)
+
+		return
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return
+	}
+	select {
+	case c.calls <- call:
+	default:
+	}
+}
+
+// redact returns a copy of message with pseudonymized user IDs.
+func (c *Capturer) redact(message proto.Message) proto.Message {
+	if !c.config.RedactUserIDs {
+		return message
+	}
+
+	message = proto.Clone(message)
+	c.redactUserIDs(message.ProtoReflect())
+
+	return message
+}
+
+func (c *Capturer) redactUserIDs(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Kind() == protoreflect.StringKind && !fd.IsList() && (fd.Name() == "userId" || fd.Name() == "requesterUserId"):
+			m.Set(fd, protoreflect.ValueOfString(c.pseudonym(v.String())))
+		case fd.Kind() == protoreflect.MessageKind && fd.IsList():
+			for i := 0; i < v.List().Len(); i++ {
+				c.redactUserIDs(v.List().Get(i).Message())
+			}
+		case fd.Kind() == protoreflect.MessageKind && !fd.IsMap():
+			c.redactUserIDs(v.Message())
+		}
+
+		return true
+	})
+}
+
+func (c *Capturer) pseudonym(userID string) string {
+	if userID == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(userID))
+
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+func (c *Capturer) run() {
+	defer close(c.done)
+
+	full := c.full()
+	for call := range c.calls {
+		if full {
+			continue
+		}
+		if err := c.writer.Write(call); err != nil {
+			slog.Error("failed to write captured call", "error", err)
+		}
+		full = c.full()
+	}
+}
+
+func (c *Capturer) full() bool {
+	if c.config.MaxBytes > 0 && c.writer.Written() >= c.config.MaxBytes {
+		slog.Warn("capture file reached its maximum size, capture stopped", "bytes", c.writer.Written())
+
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package capture
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+)
+
+const beforeWritePlayerRecord = "/accelbyte.cloudsave.validator.CloudsaveValidatorService/BeforeWritePlayerRecord"
+
+// captureCall sends a call to BeforeWritePlayerRecord of userID through the
+// interceptor of c.
+func captureCall(t *testing.T, c *Capturer, userID string) {
+	t.Helper()
+
+	request := &pb.PlayerRecord{Key: "favourite_weapon", UserId: userID, Payload: []byte(`{}`)}
+	handler := func(context.Context, any) (any, error) {
+		return &pb.PlayerRecordValidationResult{Key: request.Key, UserId: userID, IsSuccess: true}, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: beforeWritePlayerRecord}
+	if _, err := c.UnaryServerInterceptor()(context.Background(), request, info, handler); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func openCaptureFile(t *testing.T, path string) *os.File {
+	t.Helper()
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return file
+}
+
+func readCalls(t *testing.T, path string, format Format) []*pb.CapturedCall {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var calls []*pb.CapturedCall
+	reader := NewReader(file, format)
+	for {
+		call, err := reader.Read()
+		if err != nil {
+			break
+		}
+		calls = append(calls, call)
+	}
+
+	return calls
+}
+
+func TestCapturerMaxBytesAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capture.json")
+	config := Config{SampleRate: 1, BufferSize: 10}
+
+	// the first call alone reaches the limit
+	c := NewCapturer(openCaptureFile(t, path), FormatJSON, config)
+	captureCall(t, c, "user_1")
+	if err := c.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// after a restart, the calls of the previous run count towards the limit
+	config.MaxBytes = info.Size()
+	c = NewCapturer(openCaptureFile(t, path), FormatJSON, config)
+	captureCall(t, c, "user_2")
+	if err = c.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := readCalls(t, path, FormatJSON)
+	if len(calls) != 1 {
+		t.Fatalf("expected only the call before the restart, got %d calls", len(calls))
+	}
+	var request pb.PlayerRecord
+	if err = calls[0].GetRequest().UnmarshalTo(&request); err != nil {
+		t.Fatal(err)
+	}
+	if request.GetUserId() != "user_1" {
+		t.Errorf("captured the call of %q, want user_1", request.GetUserId())
+	}
+}
+
+func TestCapturerRedactsUserIDs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capture.bin")
+	c := NewCapturer(openCaptureFile(t, path), FormatProtobuf, Config{SampleRate: 1, RedactUserIDs: true, BufferSize: 10})
+	captureCall(t, c, "user_1")
+	captureCall(t, c, "user_1")
+	captureCall(t, c, "user_2")
+	if err := c.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := readCalls(t, path, FormatProtobuf)
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 calls, got %d", len(calls))
+	}
+	pseudonyms := make([]string, len(calls))
+	for i, call := range calls {
+		var request pb.PlayerRecord
+		var response pb.PlayerRecordValidationResult
+		if err := call.GetRequest().UnmarshalTo(&request); err != nil {
+			t.Fatal(err)
+		}
+		if err := call.GetResponse().UnmarshalTo(&response); err != nil {
+			t.Fatal(err)
+		}
+		if request.GetUserId() != response.GetUserId() {
+			t.Errorf("call %d: request user %q and response user %q differ", i, request.GetUserId(), response.GetUserId())
+		}
+		pseudonyms[i] = request.GetUserId()
+	}
+
+	if pseudonyms[0] == "user_1" || pseudonyms[2] == "user_2" {
+		t.Errorf("user IDs are not redacted: %v", pseudonyms)
+	}
+	if pseudonyms[0] != pseudonyms[1] || pseudonyms[0] == pseudonyms[2] {
+		t.Errorf("a user ID must always get the same pseudonym, and only its own: %v", pseudonyms)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package capture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Kind of a Change between two rule sets.
+const (
+	// ChangeFlipped is a record accepted by one rule set and rejected by the
+	// other.
+	ChangeFlipped = "flipped"
+	// ChangeErrorCode is a record rejected by both rule sets with different
+	// error codes.
+	ChangeErrorCode = "error-code"
+	// ChangeStatus is a record whose call failed with one rule set, or failed
+	// with a different status.
+	ChangeStatus = "status"
+)
+
+// Change is a record whose outcome differs between two rule sets.
+type Change struct {
+	Time      time.Time `json:"time"`
+	Method    string    `json:"method"`
+	Index     int       `json:"index"`
+	Kind      string    `json:"kind"`
+	Base      Outcome   `json:"base"`
+	Candidate Outcome   `json:"candidate"`
+}
+
+// KeyCounts are the counts of the records of a key.
+type KeyCounts struct {
+	Records           int `json:"records"`
+	BaseRejected      int `json:"baseRejected"`
+	CandidateRejected int `json:"candidateRejected"`
+	Flipped           int `json:"flipped"`
+	ErrorCodeChanged  int `json:"errorCodeChanged"`
+	StatusChanged     int `json:"statusChanged"`
+}
+
+// RuleSetDiff compares the outcomes of two rule sets over captured calls.
+type RuleSetDiff struct {
+	Calls   int                   `json:"calls"`
+	Records int                   `json:"records"`
+	Changes []Change              `json:"changes"`
+	Keys    map[string]*KeyCounts `json:"keys"`
+}
+
+// DiffRuleSets evaluates every call read from r with the base and the
+// candidate targets, and reports the records whose outcome changed. The
+// captured results are ignored.
+func DiffRuleSets(ctx context.Context, r *Reader, base Target, candidate Target) (*RuleSetDiff, error) {
+	diff := &RuleSetDiff{Changes: []Change{}, Keys: map[string]*KeyCounts{}}
+	for {
+		call, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return diff, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// both rule sets are evaluated at the time of the capture
+		callCtx := callContext(ctx, call)
+		baseOutcomes, err := Invoke(callCtx, base, call)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", diff.Calls+1, err)
+		}
+		candidateOutcomes, err := Invoke(callCtx, candidate, call)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", diff.Calls+1, err)
+		}
+
+		diff.Calls++
+		for i := 0; i < len(baseOutcomes) && i < len(candidateOutcomes); i++ {
+			b, c := baseOutcomes[i], candidateOutcomes[i]
+			counts := diff.Keys[b.Key]
+			if counts == nil {
+				counts = &KeyCounts{}
+				diff.Keys[b.Key] = counts
+			}
+
+			diff.Records++
+			counts.Records++
+			if b.Status == "" && !b.IsSuccess {
+				counts.BaseRejected++
+			}
+			if c.Status == "" && !c.IsSuccess {
+				counts.CandidateRejected++
+			}
+
+			var kind string
+			switch {
+			case b.Status != c.Status:
+				kind = ChangeStatus
+				counts.StatusChanged++
+			case b.IsSuccess != c.IsSuccess:
+				kind = ChangeFlipped
+				counts.Flipped++
+			case b.ErrorCode != c.ErrorCode:
+				kind = ChangeErrorCode
+				counts.ErrorCodeChanged++
+			default:
+				continue
+			}
+			diff.Changes = append(diff.Changes, Change{
+				Time:      call.GetTime().AsTime(),
+				Method:    call.GetMethod(),
+				Index:     i,
+				Kind:      kind,
+				Base:      b,
+				Candidate: c,
+			})
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package capture
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+)
+
+// Format is the encoding of a capture file.
+type Format string
+
+const (
+	// FormatProtobuf writes size-delimited pb.CapturedCall messages.
+	FormatProtobuf Format = "protobuf"
+	// FormatJSON writes a pb.CapturedCall JSON object per line.
+	FormatJSON Format = "json"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case FormatProtobuf:
+		return FormatProtobuf, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("invalid capture format %q", s)
+	}
+}
+
+// Writer writes captured calls, it is safe for concurrent use.
+type Writer struct {
+	mu      sync.Mutex
+	w       io.Writer
+	format  Format
+	written int64
+}
+
+func NewWriter(w io.Writer, format Format) *Writer {
+	return &Writer{w: w, format: format}
+}
+
+func (w *Writer) Write(call *pb.CapturedCall) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var n int
+	var err error
+	switch w.format {
+	case FormatJSON:
+		var data []byte
+		if data, err = protojson.Marshal(call); err != nil {
+			return err
+		}
+		// protojson randomly adds spaces to its output, it is compacted to
+		// keep the files stable
+		var buf bytes.Buffer
+		if err = json.Compact(&buf, data); err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+		n, err = w.w.Write(buf.Bytes())
+	default:
+		n, err = protodelim.MarshalTo(w.w, call)
+	}
+	w.written += int64(n)
+
+	return err
+}
+
+// Written returns the number of bytes written.
+func (w *Writer) Written() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.written
+}
+
+// Reader reads the captured calls of a capture file.
+type Reader struct {
+	r      *bufio.Reader
+	format Format
+}
+
+func NewReader(r io.Reader, format Format) *Reader {
+	return &Reader{r: bufio.NewReaderSize(r, 64*1024), format: format}
+}
+
+// Read returns the next captured call, or io.EOF at the end of the file.
+func (r *Reader) Read() (*pb.CapturedCall, error) {
+	call := &pb.CapturedCall{}
+	switch r.format {
+	case FormatJSON:
+		for {
+			line, err := r.r.ReadBytes('\n')
+			if len(bytes.TrimSpace(line)) > 0 {
+				if err = protojson.Unmarshal(line, call); err != nil {
+					return nil, err
+				}
+
+				return call, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		if err := protodelim.UnmarshalFrom(r.r, call); err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, fmt.Errorf("truncated capture file: %w", err)
+			}
+
+			return nil, err
+		}
+
+		return call, nil
+	}
+}
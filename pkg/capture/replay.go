@@ -0,0 +1,282 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package capture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/server"
+)
+
+// Target is a validator captured calls are replayed against.
+type Target interface {
+	Invoke(ctx context.Context, method string, request proto.Message) (proto.Message, error)
+}
+
+type serverTarget struct {
+	srv pb.CloudsaveValidatorServiceServer
+}
+
+// NewServerTarget calls srv in-process, through the same method handlers the
+// gRPC server dispatches calls to. Interceptors are not run.
+func NewServerTarget(srv pb.CloudsaveValidatorServiceServer) Target {
+	return &serverTarget{srv: srv}
+}
+
+func (t *serverTarget) Invoke(ctx context.Context, method string, request proto.Message) (proto.Message, error) {
+	name := method[strings.LastIndex(method, "/")+1:]
+	for _, m := range pb.CloudsaveValidatorService_ServiceDesc.Methods {
+		if m.MethodName != name {
+			continue
+		}
+
+		dec := func(v any) error {
+			proto.Merge(v.(proto.Message), request)
+
+			return nil
+		}
+		response, err := m.Handler(t.srv, ctx, dec, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return response.(proto.Message), nil
+	}
+
+	return nil, status.Errorf(codes.Unimplemented, "unknown method %s", method)
+}
+
+type clientTarget struct {
+	conn grpc.ClientConnInterface
+}
+
+// NewClientTarget calls a validator over gRPC.
+func NewClientTarget(conn grpc.ClientConnInterface) Target {
+	return &clientTarget{conn: conn}
+}
+
+func (t *clientTarget) Invoke(ctx context.Context, method string, request proto.Message) (proto.Message, error) {
+	fullName := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(method, "/"), "/", "."))
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(fullName)
+	if err != nil {
+		return nil, status.Errorf(codes.Unimplemented, "unknown method %s", method)
+	}
+	methodDescriptor, ok := descriptor.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "unknown method %s", method)
+	}
+	responseType, err := protoregistry.GlobalTypes.FindMessageByName(methodDescriptor.Output().FullName())
+	if err != nil {
+		return nil, err
+	}
+
+	response := responseType.New().Interface()
+	if err = t.conn.Invoke(ctx, method, request, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Outcome is the validation result of a record. Status is the gRPC status
+// code of the call when it failed, in which case the other results are unset.
+type Outcome struct {
+	Key       string `json:"key"`
+	UserID    string `json:"userId,omitempty"`
+	Status    string `json:"status,omitempty"`
+	IsSuccess bool   `json:"isSuccess"`
+	ErrorCode int32  `json:"errorCode,omitempty"`
+}
+
+// Outcomes returns the outcome of each record of request, in order, given the
+// response or error of the call.
+func Outcomes(request proto.Message, response proto.Message, err error) []Outcome {
+	if err != nil || response == nil {
+		code := status.Code(err)
+		if code == codes.OK {
+			code = codes.Unknown
+		}
+		records := items(request.ProtoReflect())
+		outcomes := make([]Outcome, 0, len(records))
+		for _, r := range records {
+			outcomes = append(outcomes, Outcome{
+				Key:    stringField(r, "key"),
+				UserID: stringField(r, "userId"),
+				Status: code.String(),
+			})
+		}
+
+		return outcomes
+	}
+
+	results := items(response.ProtoReflect())
+	outcomes := make([]Outcome, 0, len(results))
+	for _, r := range results {
+		outcome := Outcome{
+			Key:    stringField(r, "key"),
+			UserID: stringField(r, "userId"),
+		}
+		if fd := r.Descriptor().Fields().ByName("isSuccess"); fd != nil {
+			outcome.IsSuccess = r.Get(fd).Bool()
+		}
+		if fd := r.Descriptor().Fields().ByName("error"); fd != nil && r.Has(fd) {
+			outcome.ErrorCode = r.Get(fd).Message().Interface().(*pb.Error).GetErrorCode()
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	return outcomes
+}
+
+// items returns m when it is a record or a result, or the elements of its
+// list field when it is a bulk message.
+func items(m protoreflect.Message) []protoreflect.Message {
+	if m.Descriptor().Fields().ByName("key") != nil {
+		return []protoreflect.Message{m}
+	}
+
+	var list []protoreflect.Message
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !fd.IsList() || fd.Kind() != protoreflect.MessageKind {
+			continue
+		}
+		l := m.Get(fd).List()
+		for j := 0; j < l.Len(); j++ {
+			list = append(list, l.Get(j).Message())
+		}
+	}
+
+	return list
+}
+
+func stringField(m protoreflect.Message, name protoreflect.Name) string {
+	if fd := m.Descriptor().Fields().ByName(name); fd != nil && fd.Kind() == protoreflect.StringKind {
+		return m.Get(fd).String()
+	}
+
+	return ""
+}
+
+// CapturedOutcomes returns the outcomes recorded in call.
+func CapturedOutcomes(call *pb.CapturedCall) ([]Outcome, error) {
+	request, err := call.GetRequest().UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+
+	var response proto.Message
+	var callErr error
+	if call.GetResponse() != nil {
+		if response, err = call.GetResponse().UnmarshalNew(); err != nil {
+			return nil, fmt.Errorf("response: %w", err)
+		}
+	} else {
+		callErr = status.Error(codes.Code(call.GetStatusCode()), call.GetStatusMessage())
+	}
+
+	return Outcomes(request, response, callErr), nil
+}
+
+// callContext returns the context of the evaluation of call, under which an
+// in-process validator takes the time of the capture as the current time, so
+// the time-based rules evaluate the records as they were when captured. A
+// validator called over gRPC uses its own clock.
+func callContext(ctx context.Context, call *pb.CapturedCall) context.Context {
+	if call.GetTime() == nil {
+		return ctx
+	}
+
+	return server.ContextWithNow(ctx, call.GetTime().AsTime())
+}
+
+// Invoke replays call against target and returns the outcomes.
+func Invoke(ctx context.Context, target Target, call *pb.CapturedCall) ([]Outcome, error) {
+	request, err := call.GetRequest().UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+
+	response, err := target.Invoke(ctx, call.GetMethod(), request)
+
+	return Outcomes(request, response, err), nil
+}
+
+// Difference is a record whose replayed outcome differs from the captured
+// outcome.
+type Difference struct {
+	Time     time.Time `json:"time"`
+	Method   string    `json:"method"`
+	Index    int       `json:"index"`
+	Captured Outcome   `json:"captured"`
+	Replayed Outcome   `json:"replayed"`
+}
+
+// ReplayReport is the result of a replay.
+type ReplayReport struct {
+	Calls       int          `json:"calls"`
+	Records     int          `json:"records"`
+	Differences []Difference `json:"differences"`
+}
+
+// Replay replays every call read from r against target and reports the
+// records whose success, error code or call status differ from the capture.
+// Error messages are not compared, they may be localized or contain times.
+func Replay(ctx context.Context, r *Reader, target Target) (*ReplayReport, error) {
+	report := &ReplayReport{Differences: []Difference{}}
+	for {
+		call, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return report, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		captured, err := CapturedOutcomes(call)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", report.Calls+1, err)
+		}
+		replayed, err := Invoke(callContext(ctx, call), target, call)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", report.Calls+1, err)
+		}
+
+		report.Calls++
+		report.Records += max(len(captured), len(replayed))
+		for i := 0; i < max(len(captured), len(replayed)); i++ {
+			var capturedOutcome, replayedOutcome Outcome
+			if i < len(captured) {
+				capturedOutcome = captured[i]
+			}
+			if i < len(replayed) {
+				replayedOutcome = replayed[i]
+			}
+			if capturedOutcome != replayedOutcome {
+				report.Differences = append(report.Differences, Difference{
+					Time:     call.GetTime().AsTime(),
+					Method:   call.GetMethod(),
+					Index:    i,
+					Captured: capturedOutcome,
+					Replayed: replayedOutcome,
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package capture
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/errorcode"
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/server"
+)
+
+const afterReadGameRecord = "/accelbyte.cloudsave.validator.CloudsaveValidatorService/AfterReadGameRecord"
+
+func newCall(t *testing.T, at time.Time, method string, request proto.Message, response proto.Message) *pb.CapturedCall {
+	t.Helper()
+
+	call := &pb.CapturedCall{Time: timestamppb.New(at), Method: method}
+	var err error
+	if call.Request, err = anypb.New(request); err != nil {
+		t.Fatal(err)
+	}
+	if call.Response, err = anypb.New(response); err != nil {
+		t.Fatal(err)
+	}
+
+	return call
+}
+
+func writeCalls(t *testing.T, format Format, calls ...*pb.CapturedCall) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, format)
+	for _, call := range calls {
+		if err := w.Write(call); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return &buf
+}
+
+// dailyMessageCall is a read of a daily message captured an hour before it
+// became available, and rejected.
+func dailyMessageCall(t *testing.T) *pb.CapturedCall {
+	capturedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	request := &pb.GameRecord{
+		Key:     "daily_msg",
+		Payload: []byte(`{"title":"Hello","message":"Welcome","availableOn":"2024-03-01T01:00:00Z"}`),
+	}
+	response := &pb.GameRecordValidationResult{
+		Key:   "daily_msg",
+		Error: &pb.Error{ErrorCode: errorcode.NotYetAvailable.Code},
+	}
+
+	return newCall(t, capturedAt, afterReadGameRecord, request, response)
+}
+
+func TestReplayEvaluatesAtCaptureTime(t *testing.T) {
+	for _, format := range []Format{FormatProtobuf, FormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			buf := writeCalls(t, format, dailyMessageCall(t))
+			target := NewServerTarget(server.NewCloudsaveValidationServiceServer())
+
+			report, err := Replay(context.Background(), NewReader(buf, format), target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report.Calls != 1 || report.Records != 1 {
+				t.Errorf("replayed %d calls and %d records, want 1 and 1", report.Calls, report.Records)
+			}
+			if len(report.Differences) != 0 {
+				t.Errorf("expected no difference, got %+v", report.Differences)
+			}
+		})
+	}
+}
+
+func TestReplayReportsDifferences(t *testing.T) {
+	call := dailyMessageCall(t)
+	// captured as accepted, the validator now rejects it
+	call.Response, _ = anypb.New(&pb.GameRecordValidationResult{Key: "daily_msg", IsSuccess: true})
+	buf := writeCalls(t, FormatProtobuf, call)
+	target := NewServerTarget(server.NewCloudsaveValidationServiceServer())
+
+	report, err := Replay(context.Background(), NewReader(buf, FormatProtobuf), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Differences) != 1 {
+		t.Fatalf("expected 1 difference, got %+v", report.Differences)
+	}
+	d := report.Differences[0]
+	if !d.Captured.IsSuccess || d.Replayed.IsSuccess || d.Replayed.ErrorCode != errorcode.NotYetAvailable.Code {
+		t.Errorf("unexpected difference %+v", d)
+	}
+}
+
+func TestDiffRuleSetsEvaluatesAtCaptureTime(t *testing.T) {
+	base := server.NewCloudsaveValidationServiceServer()
+	candidate := server.NewCloudsaveValidationServiceServer()
+	err := candidate.LoadRuleSet(&server.RuleSetConfig{
+		Version: "v2",
+		Rules:   []server.RuleConfig{{Name: "daily_msg_availability", Disabled: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := writeCalls(t, FormatJSON, dailyMessageCall(t))
+
+	diff, err := DiffRuleSets(context.Background(), NewReader(buf, FormatJSON), NewServerTarget(base), NewServerTarget(candidate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the message was not available yet when captured, only the base
+	// rejects it
+	if diff.Calls != 1 || diff.Records != 1 || len(diff.Changes) != 1 {
+		t.Fatalf("unexpected diff %+v", diff)
+	}
+	change := diff.Changes[0]
+	if change.Kind != ChangeFlipped || change.Base.ErrorCode != errorcode.NotYetAvailable.Code || !change.Candidate.IsSuccess {
+		t.Errorf("unexpected change %+v", change)
+	}
+	if counts := diff.Keys["daily_msg"]; counts == nil || counts.BaseRejected != 1 || counts.CandidateRejected != 0 || counts.Flipped != 1 {
+		t.Errorf("unexpected counts %+v", counts)
+	}
+}
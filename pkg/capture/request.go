@@ -0,0 +1,74 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package capture
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+)
+
+// NewRequest builds the request of hook, the name of a
+// CloudsaveValidatorService method, from the protojson encoding of a record.
+// Bulk hooks get a request holding the record alone.
+func NewRequest(hook string, data []byte) (proto.Message, error) {
+	name := protoreflect.FullName(pb.CloudsaveValidatorService_ServiceDesc.ServiceName + "." + hook)
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown hook %q", hook)
+	}
+	method, ok := descriptor.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("unknown hook %q", hook)
+	}
+
+	requestType, err := protoregistry.GlobalTypes.FindMessageByName(method.Input().FullName())
+	if err != nil {
+		return nil, err
+	}
+	request := requestType.New()
+
+	record := request
+	var list protoreflect.List
+	if request.Descriptor().Fields().ByName("key") == nil {
+		// Bulk requests have a single repeated field of records
+		fd := request.Descriptor().Fields().Get(0)
+		list = request.Mutable(fd).List()
+		record = list.NewElement().Message()
+	}
+	if err = protojson.Unmarshal(data, record.Interface()); err != nil {
+		return nil, fmt.Errorf("record: %w", err)
+	}
+	if list != nil {
+		list.Append(protoreflect.ValueOfMessage(record))
+	}
+
+	return request.Interface(), nil
+}
+
+// ErrorMessage returns the error message of the first result of response.
+func ErrorMessage(response proto.Message) string {
+	switch r := response.(type) {
+	case *pb.GameRecordValidationResult:
+		return r.GetError().GetErrorMessage()
+	case *pb.PlayerRecordValidationResult:
+		return r.GetError().GetErrorMessage()
+	case *pb.BulkGameRecordValidationResult:
+		if len(r.GetValidationResults()) > 0 {
+			return r.GetValidationResults()[0].GetError().GetErrorMessage()
+		}
+	case *pb.BulkPlayerRecordValidationResult:
+		if len(r.GetValidationResults()) > 0 {
+			return r.GetValidationResults()[0].GetError().GetErrorMessage()
+		}
+	}
+
+	return ""
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/AccelByte/accelbyte-go-sdk/iam-sdk/pkg/iamclientmodels"
 	"github.com/AccelByte/accelbyte-go-sdk/services-api/pkg/service/iam"
 	"github.com/AccelByte/accelbyte-go-sdk/services-api/pkg/utils/auth/validator"
+	grpcMiddleware "github.com/grpc-ecosystem/go-grpc-middleware/v2"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -24,28 +25,53 @@ import (
 
 var Validator validator.AuthTokenValidator
 
+// Namespace is the namespace the access tokens must be valid for.
+var Namespace = os.Getenv("AB_NAMESPACE")
+
 func UnaryAuthServerIntercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	if !skipCheckAuthorizationMetadata(info.FullMethod) {
-		err := checkAuthorizationMetadata(ctx)
+	return NewUnaryAuthServerInterceptor(nil)(ctx, req, info, handler)
+}
+
+func StreamAuthServerIntercept(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return NewStreamAuthServerInterceptor(nil)(srv, ss, info, handler)
+}
 
-		if err != nil {
-			return nil, err
+// NewUnaryAuthServerInterceptor returns an interceptor that also requires the
+// token to be granted permission, when not nil. "{namespace}" in the resource
+// of the permission is replaced by AB_NAMESPACE.
+func NewUnaryAuthServerInterceptor(permission *iam.Permission) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !skipCheckAuthorizationMetadata(info.FullMethod) {
+			var err error
+			ctx, err = checkAuthorizationMetadata(ctx, permission)
+
+			if err != nil {
+				return nil, err
+			}
 		}
-	}
 
-	return handler(ctx, req)
+		return handler(ctx, req)
+	}
 }
 
-func StreamAuthServerIntercept(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-	if !skipCheckAuthorizationMetadata(info.FullMethod) {
-		err := checkAuthorizationMetadata(ss.Context())
+// NewStreamAuthServerInterceptor is the stream counterpart of
+// NewUnaryAuthServerInterceptor.
+func NewStreamAuthServerInterceptor(permission *iam.Permission) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !skipCheckAuthorizationMetadata(info.FullMethod) {
+			ctx, err := checkAuthorizationMetadata(ss.Context(), permission)
 
-		if err != nil {
-			return err
+			if err != nil {
+				return err
+			}
+
+			wrapped := grpcMiddleware.WrapServerStream(ss)
+			wrapped.WrappedContext = ctx
+			ss = wrapped
 		}
-	}
 
-	return handler(srv, ss)
+		return handler(srv, ss)
+	}
 }
 
 func skipCheckAuthorizationMetadata(fullMethod string) bool {
@@ -60,36 +86,51 @@ func skipCheckAuthorizationMetadata(fullMethod string) bool {
 	return false
 }
 
-func checkAuthorizationMetadata(ctx context.Context) error {
+// checkAuthorizationMetadata validates the bearer token of the request, and
+// its permission when not nil, and returns the context carrying the token
+// claims. Without a permission to check, a client certificate whose identity
+// is in PeerIdentities is accepted instead of a token.
+func checkAuthorizationMetadata(ctx context.Context, permission *iam.Permission) (context.Context, error) {
 	if Validator == nil {
-		return status.Error(codes.Internal, "authorization token validator is not set")
+		return ctx, status.Error(codes.Internal, "authorization token validator is not set")
+	}
+
+	if identity := verifiedPeerIdentity(ctx); identity != "" {
+		ctx = ContextWithPeerIdentity(ctx, identity)
+		if permission == nil && peerIdentityAllowed(identity) {
+			return ctx, nil
+		}
 	}
 
 	meta, found := metadata.FromIncomingContext(ctx)
 
 	if !found {
-		return status.Error(codes.Unauthenticated, "metadata is missing")
+		return ctx, status.Error(codes.Unauthenticated, "metadata is missing")
 	}
 
 	if _, ok := meta["authorization"]; !ok {
-		return status.Error(codes.Unauthenticated, "authorization metadata is missing")
+		return ctx, status.Error(codes.Unauthenticated, "authorization metadata is missing")
 	}
 
 	if len(meta["authorization"]) == 0 {
-		return status.Error(codes.Unauthenticated, "authorization metadata length is 0")
+		return ctx, status.Error(codes.Unauthenticated, "authorization metadata length is 0")
 	}
 
 	authorization := meta["authorization"][0]
 	token := strings.TrimPrefix(authorization, "Bearer ")
-	namespace := os.Getenv("AB_NAMESPACE")
+	namespace := Namespace
 
-	err := Validator.Validate(token, nil, &namespace, nil)
+	err := Validator.Validate(token, permission, &namespace, nil)
 
 	if err != nil {
-		return status.Error(codes.PermissionDenied, err.Error())
+		return ctx, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if claims, err := parseTokenClaims(token); err == nil {
+		ctx = ContextWithTokenClaims(ctx, claims)
 	}
 
-	return nil
+	return ctx, nil
 }
 
 func NewTokenValidator(authService iam.OAuth20Service, refreshInterval time.Duration, validateLocally bool) validator.AuthTokenValidator {
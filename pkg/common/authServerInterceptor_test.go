@@ -0,0 +1,86 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AccelByte/accelbyte-go-sdk/services-api/pkg/service/iam"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// grantsValidator accepts the tokens it knows, when they are granted the
+// permission checked.
+type grantsValidator map[string][]iam.Permission
+
+func (v grantsValidator) Initialize(...context.Context) error {
+	return nil
+}
+
+func (v grantsValidator) Validate(token string, permission *iam.Permission, _ *string, _ *string) error {
+	grants, found := v[token]
+	if !found {
+		return errors.New("invalid token")
+	}
+	if permission == nil {
+		return nil
+	}
+	for _, grant := range grants {
+		if grant.Resource == permission.Resource && grant.Action&permission.Action == permission.Action {
+			return nil
+		}
+	}
+
+	return errors.New("insufficient permissions")
+}
+
+func TestPermissionInterceptor(t *testing.T) {
+	permission := &iam.Permission{Resource: "ADMIN:NAMESPACE:mygame:CLOUDSAVE:VALIDATOR", Action: 4}
+	previous := Validator
+	Validator = grantsValidator{
+		"player": nil,
+		"reader": {{Resource: permission.Resource, Action: 2}},
+		"admin":  {{Resource: permission.Resource, Action: 6}},
+	}
+	defer func() { Validator = previous }()
+
+	interceptor := NewUnaryAuthServerInterceptor(permission)
+	info := &grpc.UnaryServerInfo{FullMethod: "/accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService/Evaluate"}
+	call := func(md metadata.MD) error {
+		_, err := interceptor(metadata.NewIncomingContext(context.Background(), md), nil, info, func(context.Context, interface{}) (interface{}, error) {
+			return "response", nil
+		})
+
+		return err
+	}
+
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want codes.Code
+	}{
+		{"no token", metadata.MD{}, codes.Unauthenticated},
+		{"unknown token", metadata.Pairs("authorization", "Bearer unknown"), codes.PermissionDenied},
+		{"token without the permission", metadata.Pairs("authorization", "Bearer player"), codes.PermissionDenied},
+		{"token without the action", metadata.Pairs("authorization", "Bearer reader"), codes.PermissionDenied},
+		{"token with the permission", metadata.Pairs("authorization", "Bearer admin"), codes.OK},
+	}
+	for _, tt := range tests {
+		if got := status.Code(call(tt.md)); got != tt.want {
+			t.Errorf("%s: status %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	// the same token is enough without a permission to check
+	if _, err := NewUnaryAuthServerInterceptor(nil)(metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer player")), nil, info,
+		func(context.Context, interface{}) (interface{}, error) { return "response", nil }); err != nil {
+		t.Errorf("token rejected without a permission: %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const method = "/accelbyte.cloudsave.validator.CloudsaveValidatorService/AfterBulkReadGameRecord"
+
+// chain calls handler through the timeout and recovery interceptors, in the
+// order of the server.
+func chain(timeout grpc.UnaryServerInterceptor, handler grpc.UnaryHandler) (interface{}, error) {
+	recoverer := recovery.UnaryServerInterceptor(recovery.WithRecoveryHandlerContext(RecoverPanic))
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+
+	return timeout(context.Background(), "request", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return recoverer(ctx, req, info, handler)
+	})
+}
+
+func TestParseMethodTimeouts(t *testing.T) {
+	timeouts, err := ParseMethodTimeouts([]string{"AfterBulkRead*=30", " BeforeWrite* = 5 "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []MethodTimeout{{"AfterBulkRead*", 30 * time.Second}, {"BeforeWrite*", 5 * time.Second}}
+	if len(timeouts) != len(want) || timeouts[0] != want[0] || timeouts[1] != want[1] {
+		t.Errorf("timeouts = %+v, want %+v", timeouts, want)
+	}
+
+	for _, item := range []string{"AfterBulkRead*", "=30", "AfterBulkRead*=0", "AfterBulkRead*=1.5"} {
+		if _, err = ParseMethodTimeouts([]string{item}); err == nil {
+			t.Errorf("parsed %q", item)
+		}
+	}
+}
+
+func TestTimeoutInterceptor(t *testing.T) {
+	timeout := NewUnaryTimeoutServerInterceptor(time.Hour, []MethodTimeout{
+		{Pattern: "BeforeWrite*", Timeout: time.Hour},
+		{Pattern: "AfterBulkRead*", Timeout: 50 * time.Millisecond},
+	})
+
+	resp, err := chain(timeout, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		deadline, ok := ctx.Deadline()
+		if !ok || time.Until(deadline) > 50*time.Millisecond {
+			t.Errorf("deadline %v is not the one of the method", deadline)
+		}
+
+		return "response", nil
+	})
+	if resp != "response" || err != nil {
+		t.Errorf("got %v, %v", resp, err)
+	}
+
+	// a handler ignoring its context does not hold the call
+	release := make(chan struct{})
+	defer close(release)
+	start := time.Now()
+	_, err = chain(timeout, func(context.Context, interface{}) (interface{}, error) {
+		<-release
+
+		return "late", nil
+	})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("error = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("the call took %s", elapsed)
+	}
+}
+
+func TestRecoveryInterceptor(t *testing.T) {
+	before := recoveredPanics(t)
+
+	// the handler panics in the goroutine started by the timeout interceptor,
+	// which would crash the process if not recovered from there
+	timeout := NewUnaryTimeoutServerInterceptor(time.Minute, nil)
+	_, err := chain(timeout, func(context.Context, interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal || status.Convert(err).Message() != "panic: boom" {
+		t.Errorf("error = %v, want Internal panic: boom", err)
+	}
+	if after := recoveredPanics(t); after != before+1 {
+		t.Errorf("recovered panics = %v, want %v", after, before+1)
+	}
+}
+
+// recoveredPanics returns the total of RecoveredPanics.
+func recoveredPanics(t *testing.T) float64 {
+	t.Helper()
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(RecoveredPanics)
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var total float64
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			total += m.GetCounter().GetValue()
+		}
+	}
+
+	return total
+}
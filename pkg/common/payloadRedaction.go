@@ -0,0 +1,149 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/rand/v2"
+	"net/url"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PayloadRedactor replaces the request and response contents logged by the
+// logging interceptor with their metadata, so record payloads, which may
+// contain personal data, are not written to the logs.
+type PayloadRedactor struct {
+	// Allowlist holds the key patterns whose payloads are logged, truncated to
+	// MaxPayloadBytes.
+	Allowlist []string
+	// MaxPayloadBytes is the maximum number of payload bytes logged for an
+	// allowlisted key.
+	MaxPayloadBytes int
+	// SampleRate is the fraction, between 0 and 1, of the request and response
+	// log lines that are written.
+	SampleRate float64
+}
+
+// RedactingLogger wraps logger so the contents logged by the logging
+// interceptor are redacted and sampled by redactor.
+func RedactingLogger(logger logging.Logger, redactor *PayloadRedactor) logging.Logger {
+	return logging.LoggerFunc(func(ctx context.Context, lvl logging.Level, msg string, fields ...any) {
+		redacted := make([]any, len(fields))
+		copy(redacted, fields)
+
+		isContent := false
+		for i := 0; i+1 < len(redacted); i += 2 {
+			if name, ok := redacted[i].(string); !ok || (name != "grpc.request.content" && name != "grpc.response.content") {
+				continue
+			}
+			isContent = true
+			if message, ok := redacted[i+1].(proto.Message); ok {
+				redacted[i+1] = redactor.Redact(message)
+			}
+		}
+
+		if isContent && !redactor.sampled() {
+			return
+		}
+
+		logger.Log(ctx, lvl, msg, redacted...)
+	})
+}
+
+func (r *PayloadRedactor) sampled() bool {
+	return r.SampleRate >= 1 || rand.Float64() < r.SampleRate
+}
+
+// Redact returns the fields of message with every bytes field replaced by
+// its size and SHA-256 hash, and the query string of URLs removed.
+func (r *PayloadRedactor) Redact(message proto.Message) map[string]any {
+	return r.redact(message.ProtoReflect())
+}
+
+func (r *PayloadRedactor) redact(m protoreflect.Message) map[string]any {
+	allowed := false
+	if fd := m.Descriptor().Fields().ByName("key"); fd != nil && fd.Kind() == protoreflect.StringKind {
+		allowed = r.allowed(m.Get(fd).String())
+	}
+
+	fields := map[string]any{}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := fd.JSONName()
+		switch {
+		case fd.IsList():
+			list := v.List()
+			values := make([]any, 0, list.Len())
+			for i := 0; i < list.Len(); i++ {
+				values = append(values, r.value(fd, list.Get(i)))
+			}
+			fields[name] = values
+		case fd.Kind() == protoreflect.BytesKind:
+			payload := v.Bytes()
+			hash := sha256.Sum256(payload)
+			fields[name+"Size"] = len(payload)
+			fields[name+"Hash"] = hex.EncodeToString(hash[:])
+			if allowed {
+				if len(payload) > r.MaxPayloadBytes {
+					payload = payload[:r.MaxPayloadBytes]
+				}
+				fields[name] = string(payload)
+			}
+		case fd.Kind() == protoreflect.StringKind && fd.Name() == "url":
+			fields[name] = redactURL(v.String())
+		default:
+			fields[name] = r.value(fd, v)
+		}
+
+		return true
+	})
+
+	return fields
+}
+
+func (r *PayloadRedactor) value(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	if fd.Kind() == protoreflect.BytesKind {
+		hash := sha256.Sum256(v.Bytes())
+
+		return map[string]any{"size": len(v.Bytes()), "hash": hex.EncodeToString(hash[:])}
+	}
+	if fd.Kind() != protoreflect.MessageKind {
+		return v.Interface()
+	}
+	if ts, ok := v.Message().Interface().(*timestamppb.Timestamp); ok {
+		return ts.AsTime()
+	}
+
+	return r.redact(v.Message())
+}
+
+func (r *PayloadRedactor) allowed(key string) bool {
+	for _, pattern := range r.Allowlist {
+		if MatchWildcard(pattern, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactURL removes the query string, which holds the signature of presigned
+// binary record URLs.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "<invalid url>"
+	}
+	u.RawQuery = ""
+	u.Fragment = ""
+	u.User = nil
+
+	return u.String()
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+)
+
+func TestRedactBytes(t *testing.T) {
+	payload := []byte(`{"email":"player@example.com"}`)
+	hash := sha256.Sum256(payload)
+	redactor := &PayloadRedactor{MaxPayloadBytes: 256}
+
+	fields := redactor.Redact(&pb.GameRecord{Key: "town_map", Namespace: "mygame", Payload: payload})
+	if _, found := fields["payload"]; found {
+		t.Errorf("the payload of a key not allowlisted is logged: %v", fields)
+	}
+	if fields["payloadSize"] != len(payload) || fields["payloadHash"] != hex.EncodeToString(hash[:]) {
+		t.Errorf("size %v and hash %v are not the ones of the payload", fields["payloadSize"], fields["payloadHash"])
+	}
+	if fields["key"] != "town_map" || fields["namespace"] != "mygame" {
+		t.Errorf("other fields are redacted: %v", fields)
+	}
+}
+
+func TestRedactPresignedURL(t *testing.T) {
+	redactor := &PayloadRedactor{}
+
+	fields := redactor.Redact(&pb.GameBinaryRecord{
+		Key: "replay_1",
+		BinaryInfo: &pb.BinaryInfo{
+			Url:     "https://bucket.s3.amazonaws.com/mygame/replay_1?X-Amz-Credential=AKIA&X-Amz-Signature=abcdef#part",
+			Version: 2,
+		},
+	})
+	binaryInfo, ok := fields["binaryInfo"].(map[string]any)
+	if !ok {
+		t.Fatalf("binaryInfo is %T", fields["binaryInfo"])
+	}
+	if url := binaryInfo["url"]; url != "https://bucket.s3.amazonaws.com/mygame/replay_1" {
+		t.Errorf("url = %v, want it without query", url)
+	}
+	if binaryInfo["version"] != int32(2) {
+		t.Errorf("version = %v, want 2", binaryInfo["version"])
+	}
+}
+
+func TestRedactAllowlist(t *testing.T) {
+	redactor := &PayloadRedactor{Allowlist: []string{"town_*"}, MaxPayloadBytes: 8}
+
+	fields := redactor.Redact(&pb.BulkGameRecord{GameRecords: []*pb.GameRecord{
+		{Key: "town_map", Payload: []byte(`{"name":"Town"}`)},
+		{Key: "player_profile", Payload: []byte(`{"name":"Player"}`)},
+	}})
+	records, ok := fields["gameRecords"].([]any)
+	if !ok || len(records) != 2 {
+		t.Fatalf("gameRecords = %v", fields["gameRecords"])
+	}
+	if payload := records[0].(map[string]any)["payload"]; payload != `{"name":` {
+		t.Errorf("allowlisted payload = %v, want it truncated to 8 bytes", payload)
+	}
+	if payload, found := records[1].(map[string]any)["payload"]; found {
+		t.Errorf("payload %v of a key not allowlisted is logged", payload)
+	}
+	if size := records[0].(map[string]any)["payloadSize"]; size != 15 {
+		t.Errorf("payloadSize = %v, want the size before truncation", size)
+	}
+}
+
+func TestRedactingLoggerSampling(t *testing.T) {
+	var lines []map[string]any
+	logger := logging.LoggerFunc(func(_ context.Context, _ logging.Level, msg string, fields ...any) {
+		line := map[string]any{"msg": msg}
+		for i := 0; i+1 < len(fields); i += 2 {
+			line[fields[i].(string)] = fields[i+1]
+		}
+		lines = append(lines, line)
+	})
+	record := &pb.GameRecord{Key: "town_map", Payload: []byte(`{"name":"Town"}`)}
+	log := func(redactor *PayloadRedactor) {
+		l := RedactingLogger(logger, redactor)
+		l.Log(context.Background(), logging.LevelInfo, "request received", "grpc.request.content", record)
+		l.Log(context.Background(), logging.LevelInfo, "finished call", "grpc.code", "OK")
+	}
+
+	// every line is written, with the content redacted
+	log(&PayloadRedactor{Allowlist: []string{"town_*"}, MaxPayloadBytes: 256, SampleRate: 1})
+	if len(lines) != 2 {
+		t.Fatalf("wrote %d lines, want 2", len(lines))
+	}
+	content, ok := lines[0]["grpc.request.content"].(map[string]any)
+	if !ok || content["payloadSize"] != 15 {
+		t.Errorf("content is not redacted: %v", lines[0])
+	}
+
+	// the content lines are dropped, allowlisted or not, the others written
+	lines = nil
+	log(&PayloadRedactor{Allowlist: []string{"town_*"}, MaxPayloadBytes: 256, SampleRate: 0})
+	if len(lines) != 1 || lines[0]["msg"] != "finished call" {
+		t.Errorf("wrote %v, want only the line without content", lines)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package common
+
+import (
+	"context"
+	"crypto/x509"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// PeerIdentities are the identities of the client certificates accepted
+// instead of an access token, '*' matches any sequence of characters. Only
+// calls without a permission to check can be authorized by certificate.
+var PeerIdentities []string
+
+type peerIdentityKey struct{}
+
+func ContextWithPeerIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, peerIdentityKey{}, identity)
+}
+
+// PeerIdentityFromContext returns the identity of the verified client
+// certificate of the request, or "" when there is none or auth is disabled.
+func PeerIdentityFromContext(ctx context.Context) string {
+	identity, _ := ctx.Value(peerIdentityKey{}).(string)
+
+	return identity
+}
+
+// verifiedPeerIdentity returns the identity of the verified client
+// certificate of the call, or "" when there is none.
+func verifiedPeerIdentity(ctx context.Context) string {
+	p, found := peer.FromContext(ctx)
+	if !found {
+		return ""
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+
+	return CertificateIdentity(tlsInfo.State.VerifiedChains[0][0])
+}
+
+// CertificateIdentity returns the first URI SAN of cert, e.g. a SPIFFE ID,
+// else its first DNS SAN, else its subject common name.
+func CertificateIdentity(cert *x509.Certificate) string {
+	if len(cert.URIs) > 0 {
+		return cert.URIs[0].String()
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+
+	return cert.Subject.CommonName
+}
+
+func peerIdentityAllowed(identity string) bool {
+	for _, pattern := range PeerIdentities {
+		if MatchWildcard(pattern, identity) {
+			return true
+		}
+	}
+
+	return false
+}
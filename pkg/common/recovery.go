@@ -0,0 +1,41 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveredPanics counts the panics recovered from, by gRPC method.
+var RecoveredPanics = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "cloudsave_validator",
+	Name:      "recovered_panics_total",
+	Help:      "Number of panics recovered from, in the gRPC handlers and the rules.",
+}, []string{"method"})
+
+// PanicError logs p with the stack of the goroutine that panicked, counts it
+// and returns it as an error. It must be called by the deferred function
+// that recovered p, for the stack to be the one of the panic.
+func PanicError(ctx context.Context, p any) error {
+	method, _ := grpc.Method(ctx)
+	slog.ErrorContext(ctx, "recovered from panic", "method", method, "panic", fmt.Sprint(p), "stack", string(debug.Stack()))
+	RecoveredPanics.WithLabelValues(method).Inc()
+
+	return fmt.Errorf("panic: %v", p)
+}
+
+// RecoverPanic is the handler of the recovery interceptors, the call fails
+// with an Internal status instead of crashing the process.
+func RecoverPanic(ctx context.Context, p any) error {
+	return status.Error(codes.Internal, PanicError(ctx, p).Error())
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// MethodTimeout is the timeout of the gRPC methods whose name, without the
+// service, matches Pattern, where '*' matches any sequence of characters.
+type MethodTimeout struct {
+	Pattern string
+	Timeout time.Duration
+}
+
+// ParseMethodTimeouts parses "pattern=seconds" items, e.g.
+// "AfterBulkRead*=30".
+func ParseMethodTimeouts(items []string) ([]MethodTimeout, error) {
+	timeouts := make([]MethodTimeout, 0, len(items))
+	for _, item := range items {
+		pattern, seconds, found := strings.Cut(item, "=")
+		n, err := strconv.Atoi(strings.TrimSpace(seconds))
+		if !found || strings.TrimSpace(pattern) == "" || err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid method timeout %q, expected method=seconds", item)
+		}
+		timeouts = append(timeouts, MethodTimeout{Pattern: strings.TrimSpace(pattern), Timeout: time.Duration(n) * time.Second})
+	}
+
+	return timeouts, nil
+}
+
+// NewUnaryTimeoutServerInterceptor caps the time of the calls to the timeout
+// of the first of timeouts matching their method, else to defaultTimeout,
+// whatever the deadline set by the client. The handler gets a context with
+// that deadline; if it ignores it, the call still fails with DeadlineExceeded
+// on time and the handler is left to finish in the background.
+func NewUnaryTimeoutServerInterceptor(defaultTimeout time.Duration, timeouts []MethodTimeout) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		timeout := defaultTimeout
+		method := path.Base(info.FullMethod)
+		for _, t := range timeouts {
+			if MatchWildcard(t.Pattern, method) {
+				timeout = t.Timeout
+
+				break
+			}
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		type result struct {
+			resp interface{}
+			err  error
+		}
+		done := make(chan result, 1)
+		go func() {
+			// panics must be recovered from in this goroutine, by the
+			// interceptors after this one
+			resp, err := handler(ctx, req)
+			done <- result{resp, err}
+		}()
+
+		select {
+		case r := <-done:
+			return r.resp, r.err
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
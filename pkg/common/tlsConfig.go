@@ -0,0 +1,195 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// TLSFiles are the files of a server TLS configuration.
+type TLSFiles struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is the CA bundle client certificates are verified against,
+	// client certificates are not requested when empty.
+	ClientCAFile string
+	// ClientAuth is the policy for client certificates when ClientCAFile is
+	// set, tls.RequireAndVerifyClientCert or tls.VerifyClientCertIfGiven.
+	ClientAuth tls.ClientAuthType
+}
+
+// ReloadingTLSConfig is a server TLS configuration whose certificate, key and
+// client CA bundle are reloaded when their files change, without dropping the
+// connections established with the previous ones.
+type ReloadingTLSConfig struct {
+	files TLSFiles
+
+	mu        sync.RWMutex
+	config    *tls.Config
+	leaf      *x509.Certificate
+	modTimes  []time.Time
+	reloadErr error
+}
+
+// NewReloadingTLSConfig loads files, it fails when they are invalid.
+func NewReloadingTLSConfig(files TLSFiles) (*ReloadingTLSConfig, error) {
+	r := &ReloadingTLSConfig{files: files}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// TLSConfig returns the configuration to serve with, it always uses the files
+// last loaded.
+func (r *ReloadingTLSConfig) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			return r.config, nil
+		},
+	}
+}
+
+// Watch checks the files every interval until ctx is done, and reloads them
+// when one changed. Invalid files are logged and the previous ones kept.
+func (r *ReloadingTLSConfig) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		modTimes, err := r.fileModTimes()
+		r.mu.RLock()
+		changed := err != nil || !equalTimes(modTimes, r.modTimes)
+		r.mu.RUnlock()
+		if !changed {
+			continue
+		}
+
+		if err = r.reload(); err != nil {
+			slog.Error("failed to reload TLS files, keeping the previous ones", "error", err)
+
+			continue
+		}
+		slog.Info("reloaded TLS files", "certFile", r.files.CertFile)
+	}
+}
+
+// CheckCertificate returns an error when the last reload failed, or when the
+// certificate in use has expired.
+func (r *ReloadingTLSConfig) CheckCertificate(_ context.Context) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.reloadErr != nil {
+		return fmt.Errorf("reload failed, the previous files are still in use: %w", r.reloadErr)
+	}
+	if time.Now().After(r.leaf.NotAfter) {
+		return fmt.Errorf("certificate expired at %s", r.leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func (r *ReloadingTLSConfig) reload() error {
+	modTimes, err := r.fileModTimes()
+	if err == nil {
+		var config *tls.Config
+		var leaf *x509.Certificate
+		if config, leaf, err = r.load(); err == nil {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.config, r.leaf, r.modTimes, r.reloadErr = config, leaf, modTimes, nil
+
+			return nil
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reloadErr = err
+	// not retried until the files change again
+	if modTimes != nil {
+		r.modTimes = modTimes
+	}
+
+	return err
+}
+
+func (r *ReloadingTLSConfig) load() (*tls.Config, *x509.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(r.files.CertFile, r.files.KeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	if r.files.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.files.ClientCAFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, nil, errors.New("no certificate found in " + r.files.ClientCAFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = r.files.ClientAuth
+	}
+
+	return config, leaf, nil
+}
+
+func (r *ReloadingTLSConfig) fileModTimes() ([]time.Time, error) {
+	var modTimes []time.Time
+	for _, file := range []string{r.files.CertFile, r.files.KeyFile, r.files.ClientCAFile} {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+
+	return modTimes, nil
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package common
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// TokenClaims are the claims of a validated access token.
+type TokenClaims struct {
+	Subject   string `json:"sub"`
+	ClientID  string `json:"client_id"`
+	Namespace string `json:"namespace"`
+	Country   string `json:"country"`
+	Locale    string `json:"locale"`
+}
+
+type tokenClaimsKey struct{}
+
+func ContextWithTokenClaims(ctx context.Context, claims *TokenClaims) context.Context {
+	return context.WithValue(ctx, tokenClaimsKey{}, claims)
+}
+
+// TokenClaimsFromContext returns the claims of the token the request was
+// authorized with, or nil when auth is disabled.
+func TokenClaimsFromContext(ctx context.Context) *TokenClaims {
+	claims, _ := ctx.Value(tokenClaimsKey{}).(*TokenClaims)
+
+	return claims
+}
+
+// parseTokenClaims decodes the claims of a JWT without verifying it, the
+// token must have been validated beforehand.
+func parseTokenClaims(token string) (*TokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, err
+	}
+
+	var claims TokenClaims
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return nil, err
+	}
+
+	return &claims, nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/AccelByte/accelbyte-go-sdk/iam-sdk/pkg/iamclient/o_auth2_0"
+	"github.com/AccelByte/accelbyte-go-sdk/services-api/pkg/service/iam"
+	"github.com/AccelByte/accelbyte-go-sdk/services-api/pkg/utils/auth/validator"
+)
+
+// initializeRetryInterval is the time between two attempts to initialize the
+// token validator.
+const initializeRetryInterval = 10 * time.Second
+
+// TokenValidatorMonitor initializes a token validator, retrying until it
+// succeeds, and reports whether the JWKS the tokens are verified with can
+// still be fetched. The validator refreshes the JWKS on its own but does not
+// report failures.
+type TokenValidatorMonitor struct {
+	validator       validator.AuthTokenValidator
+	authService     iam.OAuth20Service
+	refreshInterval time.Duration
+
+	mu            sync.Mutex
+	initErr       error
+	jwksFetchedAt time.Time
+	jwksErr       error
+}
+
+func NewTokenValidatorMonitor(validator validator.AuthTokenValidator, authService iam.OAuth20Service, refreshInterval time.Duration) *TokenValidatorMonitor {
+	return &TokenValidatorMonitor{
+		validator:       validator,
+		authService:     authService,
+		refreshInterval: refreshInterval,
+		initErr:         errors.New("not initialized"),
+	}
+}
+
+// Start initializes the validator, then keeps retrying in the background
+// when it failed. The JWKS is fetched every refresh interval until ctx is
+// done. The returned error is the one of the first attempt.
+func (m *TokenValidatorMonitor) Start(ctx context.Context) error {
+	err := m.initialize(ctx)
+	go func() {
+		for err != nil {
+			select {
+			case <-time.After(initializeRetryInterval):
+			case <-ctx.Done():
+				return
+			}
+			if err = m.initialize(ctx); err != nil {
+				slog.Warn("failed to initialize token validator", "error", err)
+			}
+		}
+		slog.Info("token validator initialized")
+
+		ticker := time.NewTicker(m.refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.fetchJWKS(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return err
+}
+
+func (m *TokenValidatorMonitor) initialize(ctx context.Context) error {
+	err := m.validator.Initialize(ctx)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.initErr = err
+	if err == nil {
+		// Initialize fetches the JWKS
+		m.jwksFetchedAt = time.Now()
+	}
+
+	return err
+}
+
+func (m *TokenValidatorMonitor) fetchJWKS(ctx context.Context) {
+	_, err := m.authService.GetJWKSV3Short(&o_auth2_0.GetJWKSV3Params{Context: ctx})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jwksErr = err
+	if err == nil {
+		m.jwksFetchedAt = time.Now()
+	}
+}
+
+// CheckInitialized returns the error of the last attempt to initialize the
+// validator, until one succeeds.
+func (m *TokenValidatorMonitor) CheckInitialized(_ context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.initErr != nil {
+		return fmt.Errorf("token validator is not initialized: %w", m.initErr)
+	}
+
+	return nil
+}
+
+// CheckJWKS returns an error when the JWKS could not be fetched for maxAge.
+func (m *TokenValidatorMonitor) CheckJWKS(maxAge time.Duration) func(ctx context.Context) error {
+	return func(_ context.Context) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if m.jwksFetchedAt.IsZero() {
+			return errors.New("JWKS was never fetched")
+		}
+		if age := time.Since(m.jwksFetchedAt); age > maxAge {
+			if m.jwksErr != nil {
+				return fmt.Errorf("JWKS was last fetched %s ago: %w", age.Round(time.Second), m.jwksErr)
+			}
+
+			return fmt.Errorf("JWKS was last fetched %s ago", age.Round(time.Second))
+		}
+
+		return nil
+	}
+}
@@ -5,9 +5,17 @@
 package common
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/exporters/zipkin"
 	"go.opentelemetry.io/otel/sdk/resource"
 
@@ -15,23 +23,102 @@ import (
 	semanticConventions "go.opentelemetry.io/otel/semconv/v1.12.0"
 )
 
+// NewTracerProvider creates the tracer provider configured by the standard
+// OpenTelemetry environment variables:
+//
+//   - OTEL_TRACES_EXPORTER: zipkin (default), otlp, console or none.
+//   - OTEL_EXPORTER_OTLP_TRACES_PROTOCOL or OTEL_EXPORTER_OTLP_PROTOCOL:
+//     http/protobuf (default) or grpc, for the otlp exporter.
+//   - OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG: the sampler, defaults
+//     to parentbased_always_on.
+//   - OTEL_RESOURCE_ATTRIBUTES: additional resource attributes.
 func NewTracerProvider(serviceName string, environment string, id int64) (*sdkTrace.TracerProvider, error) {
-	zipkinEndpoint := GetEnv("OTEL_EXPORTER_ZIPKIN_ENDPOINT", "http://localhost:9411/api/v2/spans")
-	exporter, err := zipkin.New(zipkinEndpoint)
+	ctx := context.Background()
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithSchemaURL(semanticConventions.SchemaURL),
+		resource.WithAttributes(
+			semanticConventions.ServiceNameKey.String(serviceName),
+			attribute.String("environment", environment),
+			attribute.Int64("ID", id),
+		),
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	res := resource.NewWithAttributes(
-		semanticConventions.SchemaURL,
-		semanticConventions.ServiceNameKey.String(serviceName),
-		attribute.String("environment", environment),
-		attribute.Int64("ID", id),
-	)
+	sampler, err := newSampler(GetEnv("OTEL_TRACES_SAMPLER", "parentbased_always_on"), os.Getenv("OTEL_TRACES_SAMPLER_ARG"))
+	if err != nil {
+		return nil, err
+	}
 
-	return sdkTrace.NewTracerProvider(
-		sdkTrace.WithBatcher(exporter, sdkTrace.WithBatchTimeout(time.Second*1)),
+	opts := []sdkTrace.TracerProviderOption{
 		sdkTrace.WithResource(res),
-		sdkTrace.WithSampler(sdkTrace.AlwaysSample()),
-	), nil
+		sdkTrace.WithSampler(sampler),
+	}
+
+	exporter, err := newSpanExporter(ctx, strings.ToLower(GetEnv("OTEL_TRACES_EXPORTER", "zipkin")))
+	if err != nil {
+		return nil, err
+	}
+	if exporter != nil {
+		opts = append(opts, sdkTrace.WithBatcher(exporter, sdkTrace.WithBatchTimeout(time.Second*1)))
+	}
+
+	return sdkTrace.NewTracerProvider(opts...), nil
+}
+
+// newSpanExporter returns nil for the none exporter.
+func newSpanExporter(ctx context.Context, name string) (sdkTrace.SpanExporter, error) {
+	switch name {
+	case "zipkin":
+		zipkinEndpoint := GetEnv("OTEL_EXPORTER_ZIPKIN_ENDPOINT", "http://localhost:9411/api/v2/spans")
+
+		return zipkin.New(zipkinEndpoint)
+	case "otlp":
+		protocol := GetEnv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", GetEnv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/protobuf"))
+		switch protocol {
+		case "grpc":
+			return otlptracegrpc.New(ctx)
+		case "http/protobuf":
+			return otlptracehttp.New(ctx)
+		default:
+			return nil, fmt.Errorf("unsupported OTLP protocol %q", protocol)
+		}
+	case "console", "stdout":
+		return stdouttrace.New()
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported traces exporter %q", name)
+	}
+}
+
+func newSampler(name string, arg string) (sdkTrace.Sampler, error) {
+	ratio := 1.0
+	if arg != "" {
+		var err error
+		ratio, err = strconv.ParseFloat(arg, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("invalid sampler ratio %q", arg)
+		}
+	}
+
+	switch strings.ToLower(name) {
+	case "always_on":
+		return sdkTrace.AlwaysSample(), nil
+	case "always_off":
+		return sdkTrace.NeverSample(), nil
+	case "traceidratio":
+		return sdkTrace.TraceIDRatioBased(ratio), nil
+	case "parentbased_always_on":
+		return sdkTrace.ParentBased(sdkTrace.AlwaysSample()), nil
+	case "parentbased_always_off":
+		return sdkTrace.ParentBased(sdkTrace.NeverSample()), nil
+	case "parentbased_traceidratio":
+		return sdkTrace.ParentBased(sdkTrace.TraceIDRatioBased(ratio)), nil
+	default:
+		return nil, fmt.Errorf("unsupported sampler %q", name)
+	}
 }
@@ -7,6 +7,7 @@ package common
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 func GetEnv(key, fallback string) string {
@@ -26,3 +27,50 @@ func GetEnvInt(key string, fallback int) int {
 
 	return val
 }
+
+func GetEnvFloat(key string, fallback float64) float64 {
+	str := GetEnv(key, strconv.FormatFloat(fallback, 'f', -1, 64))
+	val, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return fallback
+	}
+
+	return val
+}
+
+// GetEnvList splits the comma-separated value of key, ignoring empty items.
+func GetEnvList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(GetEnv(key, ""), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+// MatchWildcard reports whether s matches pattern, where '*' in the pattern
+// matches any sequence of characters.
+func MatchWildcard(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+
+	return strings.HasSuffix(s, last)
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+// Package config loads the settings of the app. Every setting has a default
+// and can be set, from the lowest to the highest precedence, in the YAML
+// config file, with an environment variable or with a command-line flag.
+package config
+
+// Config is the configuration of the app. The tags of each setting give its
+// key in the YAML file, which is also the name of its flag, its environment
+// variable, its default and whether it is a secret, masked by `config print`.
+type Config struct {
+	LogLevel    string `yaml:"logLevel" env:"LOG_LEVEL" default:"info" usage:"debug, info, warn or error"`
+	Environment string `yaml:"environment" env:"ENVIRONMENT" default:"production" usage:"deployment environment, set on the traces"`
+	ServiceID   int64  `yaml:"serviceId" env:"SERVICE_ID" default:"1" usage:"ID of the service, set on the traces"`
+	ServiceName string `yaml:"serviceName" env:"OTEL_SERVICE_NAME" usage:"name of the service in the traces, prefixed with extend-app-cv-"`
+
+	GRPC           GRPCConfig           `yaml:"grpc"`
+	TLS            TLSConfig            `yaml:"tls"`
+	Concurrency    ConcurrencyConfig    `yaml:"concurrency"`
+	Metrics        MetricsConfig        `yaml:"metrics"`
+	Debug          DebugConfig          `yaml:"debug"`
+	Auth           AuthConfig           `yaml:"auth"`
+	Admin          AdminConfig          `yaml:"admin"`
+	Health         HealthConfig         `yaml:"health"`
+	Shutdown       ShutdownConfig       `yaml:"shutdown"`
+	Rules          RulesConfig          `yaml:"rules"`
+	Report         ReportConfig         `yaml:"validationReport"`
+	Locales        LocalesConfig        `yaml:"locales"`
+	PayloadLogging PayloadLoggingConfig `yaml:"payloadLogging"`
+	Capture        CaptureConfig        `yaml:"capture"`
+	Audit          AuditConfig          `yaml:"audit"`
+	Quarantine     QuarantineConfig     `yaml:"quarantine"`
+}
+
+type GRPCConfig struct {
+	Port int `yaml:"port" env:"GRPC_PORT" default:"6565" usage:"port of the validator gRPC service"`
+
+	TimeoutSeconds int      `yaml:"timeoutSeconds" env:"GRPC_TIMEOUT_SECONDS" default:"10" usage:"time a call of the validator gRPC service may take"`
+	MethodTimeouts []string `yaml:"methodTimeouts" env:"GRPC_METHOD_TIMEOUTS" usage:"comma-separated method=seconds timeouts overriding grpc.timeoutSeconds, '*' matches any characters"`
+
+	MaxRecvMessageMB     int `yaml:"maxRecvMessageMB" env:"GRPC_MAX_RECV_MESSAGE_MB" default:"4" usage:"maximum size of a request"`
+	MaxConcurrentStreams int `yaml:"maxConcurrentStreams" env:"GRPC_MAX_CONCURRENT_STREAMS" default:"1000" usage:"maximum calls in flight on a connection"`
+
+	KeepaliveTimeSeconds    int `yaml:"keepaliveTimeSeconds" env:"GRPC_KEEPALIVE_TIME_SECONDS" default:"60" usage:"idle time after which the server pings a connection"`
+	KeepaliveTimeoutSeconds int `yaml:"keepaliveTimeoutSeconds" env:"GRPC_KEEPALIVE_TIMEOUT_SECONDS" default:"20" usage:"time waited for a ping answer before closing the connection"`
+	KeepaliveMinTimeSeconds int `yaml:"keepaliveMinTimeSeconds" env:"GRPC_KEEPALIVE_MIN_TIME_SECONDS" default:"10" usage:"minimum interval of the client pings, connections pinging more often are closed"`
+	MaxConnectionAgeSeconds int `yaml:"maxConnectionAgeSeconds" env:"GRPC_MAX_CONNECTION_AGE_SECONDS" default:"0" usage:"time after which a connection is gracefully closed, for the load to rebalance, 0 means no limit"`
+}
+
+// ConcurrencyConfig limits the calls in flight of each class of hooks, with
+// limits adapting to the latency of the calls between the min and max.
+type ConcurrencyConfig struct {
+	Enabled          bool    `yaml:"enabled" env:"CONCURRENCY_LIMIT_ENABLED" default:"true" usage:"limit the calls in flight"`
+	OverloadPolicy   string  `yaml:"overloadPolicy" env:"CONCURRENCY_OVERLOAD_POLICY" default:"reject" usage:"reject, fail-open or fail-closed, the response to the calls over the limit"`
+	MinLimit         int     `yaml:"minLimit" env:"CONCURRENCY_MIN_LIMIT" default:"10" usage:"lowest limit of each class"`
+	WriteMaxLimit    int     `yaml:"writeMaxLimit" env:"CONCURRENCY_WRITE_MAX_LIMIT" default:"200" usage:"highest limit of the BeforeWrite hooks"`
+	ReadMaxLimit     int     `yaml:"readMaxLimit" env:"CONCURRENCY_READ_MAX_LIMIT" default:"200" usage:"highest limit of the AfterRead hooks"`
+	BulkMaxLimit     int     `yaml:"bulkMaxLimit" env:"CONCURRENCY_BULK_MAX_LIMIT" default:"20" usage:"highest limit of the AfterBulkRead hooks"`
+	LatencyTolerance float64 `yaml:"latencyTolerance" env:"CONCURRENCY_LATENCY_TOLERANCE" default:"1.5" usage:"how much slower than usual the calls may get before the limits are lowered"`
+}
+
+// TLSConfig secures the validator and admin gRPC services, TLS is enabled
+// when the certificate file is set.
+type TLSConfig struct {
+	CertFile              string   `yaml:"certFile" env:"TLS_CERT_FILE" usage:"PEM certificate of the gRPC servers, enables TLS"`
+	KeyFile               string   `yaml:"keyFile" env:"TLS_KEY_FILE" usage:"PEM private key of the certificate"`
+	ClientCAFile          string   `yaml:"clientCAFile" env:"TLS_CLIENT_CA_FILE" usage:"PEM CA bundle client certificates are verified against, enables mutual TLS"`
+	ClientAuth            string   `yaml:"clientAuth" env:"TLS_CLIENT_AUTH" default:"require" usage:"require or optional client certificates, with a client CA bundle"`
+	AllowedIdentities     []string `yaml:"allowedIdentities" env:"TLS_ALLOWED_CLIENT_IDENTITIES" usage:"comma-separated client certificate identities accepted instead of an access token"`
+	ReloadIntervalSeconds int      `yaml:"reloadIntervalSeconds" env:"TLS_RELOAD_INTERVAL_SECONDS" default:"30" usage:"interval of the checks for changed TLS files"`
+}
+
+type MetricsConfig struct {
+	Port         int    `yaml:"port" env:"METRICS_PORT" default:"8080" usage:"port of the metrics and health endpoints"`
+	Endpoint     string `yaml:"endpoint" env:"METRICS_ENDPOINT" default:"/metrics" usage:"path of the Prometheus metrics"`
+	MaxKeyLabels int    `yaml:"maxKeyLabels" env:"METRICS_MAX_KEY_LABELS" default:"100" usage:"distinct record keys used as metric labels"`
+
+	ReadTimeoutSeconds  int `yaml:"readTimeoutSeconds" env:"METRICS_READ_TIMEOUT_SECONDS" default:"10" usage:"time to read a request of the metrics server"`
+	WriteTimeoutSeconds int `yaml:"writeTimeoutSeconds" env:"METRICS_WRITE_TIMEOUT_SECONDS" default:"30" usage:"time to write a response of the metrics server"`
+	IdleTimeoutSeconds  int `yaml:"idleTimeoutSeconds" env:"METRICS_IDLE_TIMEOUT_SECONDS" default:"120" usage:"time an idle connection of the metrics server is kept"`
+}
+
+// DebugConfig configures the listener of the pprof profiles, kept apart from
+// the metrics port which is usually exposed.
+type DebugConfig struct {
+	Enabled              bool   `yaml:"enabled" env:"DEBUG_ENABLED" default:"false" usage:"serve the pprof profiles"`
+	Address              string `yaml:"address" env:"DEBUG_ADDRESS" default:"127.0.0.1:6060" usage:"address of the pprof listener, loopback only by default"`
+	Username             string `yaml:"username" env:"DEBUG_USERNAME" usage:"basic auth username of the pprof listener"`
+	Password             string `yaml:"password" env:"DEBUG_PASSWORD" secret:"true" usage:"basic auth password of the pprof listener"`
+	BlockProfileRate     int    `yaml:"blockProfileRate" env:"DEBUG_BLOCK_PROFILE_RATE" default:"0" usage:"rate of the block profile, 0 disables it"`
+	MutexProfileFraction int    `yaml:"mutexProfileFraction" env:"DEBUG_MUTEX_PROFILE_FRACTION" default:"0" usage:"fraction of the mutex contentions profiled, 0 disables it"`
+}
+
+type AuthConfig struct {
+	Enabled                bool   `yaml:"enabled" env:"PLUGIN_GRPC_SERVER_AUTH_ENABLED" default:"true" usage:"validate the access token of the calls"`
+	BaseURL                string `yaml:"baseUrl" env:"AB_BASE_URL" usage:"base URL of AccelByte Gaming Services"`
+	ClientID               string `yaml:"clientId" env:"AB_CLIENT_ID" usage:"OAuth client ID"`
+	ClientSecret           string `yaml:"clientSecret" env:"AB_CLIENT_SECRET" secret:"true" usage:"OAuth client secret"`
+	Namespace              string `yaml:"namespace" env:"AB_NAMESPACE" usage:"namespace the access tokens must be valid for"`
+	RefreshIntervalSeconds int    `yaml:"refreshIntervalSeconds" env:"REFRESH_INTERVAL" default:"600" usage:"interval of the JWKS and revocation list refresh"`
+}
+
+type AdminConfig struct {
+	Enabled            bool   `yaml:"enabled" env:"ADMIN_ENABLED" default:"false" usage:"serve the admin gRPC service"`
+	Port               int    `yaml:"port" env:"ADMIN_GRPC_PORT" default:"6566" usage:"port of the admin gRPC service"`
+	PermissionResource string `yaml:"permissionResource" env:"ADMIN_PERMISSION_RESOURCE" default:"ADMIN:NAMESPACE:{namespace}:CLOUDSAVE:VALIDATOR" usage:"permission required by the admin service"`
+	PermissionAction   int    `yaml:"permissionAction" env:"ADMIN_PERMISSION_ACTION" default:"4" usage:"action of the permission required by the admin service"`
+}
+
+type HealthConfig struct {
+	CheckIntervalSeconds int `yaml:"checkIntervalSeconds" env:"HEALTH_CHECK_INTERVAL_SECONDS" default:"10" usage:"interval of the health checks"`
+}
+
+type ShutdownConfig struct {
+	DrainSeconds   int `yaml:"drainSeconds" env:"SHUTDOWN_DRAIN_SECONDS" default:"5" usage:"time serving while not ready before stopping"`
+	TimeoutSeconds int `yaml:"timeoutSeconds" env:"SHUTDOWN_TIMEOUT_SECONDS" default:"20" usage:"time given to the calls in flight to finish"`
+}
+
+type RulesConfig struct {
+	File             string `yaml:"file" env:"RULES_CONFIG_FILE" usage:"rule set file, defaults to the built-in rules"`
+	ShadowMode       bool   `yaml:"shadowMode" env:"SHADOW_MODE_ENABLED" default:"false" usage:"force every rule into shadow mode"`
+	MaxEventBannerKB int    `yaml:"maxEventBannerKB" env:"EVENT_BANNER_MAX_SIZE_KB" default:"100" usage:"maximum size of an event banner"`
+}
+
+type ReportConfig struct {
+	Format        string `yaml:"format" env:"VALIDATION_REPORT_FORMAT" default:"json" usage:"json or text"`
+	MaxViolations int    `yaml:"maxViolations" env:"VALIDATION_REPORT_MAX_VIOLATIONS" default:"10" usage:"violations listed in a report, 0 for no limit"`
+}
+
+type LocalesConfig struct {
+	Dir     string `yaml:"dir" env:"LOCALES_DIR" usage:"directory of the message catalogs"`
+	Default string `yaml:"default" env:"DEFAULT_LOCALE" default:"en" usage:"locale of the messages when the call does not set one"`
+}
+
+type PayloadLoggingConfig struct {
+	Allowlist  []string `yaml:"allowlist" env:"LOG_PAYLOAD_ALLOWLIST" usage:"comma-separated record keys whose payloads are logged"`
+	MaxBytes   int      `yaml:"maxBytes" env:"LOG_PAYLOAD_MAX_BYTES" default:"256" usage:"payloads logged are truncated to this size"`
+	SampleRate float64  `yaml:"sampleRate" env:"LOG_PAYLOAD_SAMPLE_RATE" default:"1" usage:"fraction of the request and response log lines written, allowlisted keys included"`
+}
+
+type CaptureConfig struct {
+	File          string  `yaml:"file" env:"CAPTURE_FILE" usage:"file the calls are captured to, capture is disabled when empty"`
+	Format        string  `yaml:"format" env:"CAPTURE_FORMAT" default:"protobuf" usage:"protobuf or json"`
+	SampleRate    float64 `yaml:"sampleRate" env:"CAPTURE_SAMPLE_RATE" default:"1" usage:"fraction of the calls captured"`
+	RedactUserIDs bool    `yaml:"redactUserIds" env:"CAPTURE_REDACT_USER_IDS" default:"false" usage:"replace the user IDs with pseudonyms"`
+	MaxSizeMB     int     `yaml:"maxSizeMB" env:"CAPTURE_MAX_SIZE_MB" default:"100" usage:"capture stops at this file size, 0 means no limit"`
+	BufferSize    int     `yaml:"bufferSize" env:"CAPTURE_BUFFER_SIZE" default:"1000" usage:"calls waiting to be written"`
+}
+
+type AuditConfig struct {
+	Sink       string `yaml:"sink" env:"AUDIT_SINK" default:"none" usage:"none, stdout, file or webhook"`
+	BufferSize int    `yaml:"bufferSize" env:"AUDIT_BUFFER_SIZE" default:"1000" usage:"events waiting to be written"`
+
+	FilePath       string `yaml:"filePath" env:"AUDIT_FILE_PATH" default:"audit/rejections.jsonl" usage:"file of the file sink"`
+	FileMaxSizeMB  int    `yaml:"fileMaxSizeMB" env:"AUDIT_FILE_MAX_SIZE_MB" default:"100" usage:"size at which the audit file is rotated"`
+	FileMaxBackups int    `yaml:"fileMaxBackups" env:"AUDIT_FILE_MAX_BACKUPS" default:"5" usage:"rotated audit files kept"`
+
+	WebhookURL            string `yaml:"webhookUrl" env:"AUDIT_WEBHOOK_URL" secret:"true" usage:"URL of the webhook sink"`
+	WebhookTimeoutSeconds int    `yaml:"webhookTimeoutSeconds" env:"AUDIT_WEBHOOK_TIMEOUT_SECONDS" default:"5" usage:"timeout of a webhook call"`
+}
+
+type QuarantineConfig struct {
+	Store          string `yaml:"store" env:"QUARANTINE_STORE" default:"none" usage:"none, dir or s3"`
+	Dir            string `yaml:"dir" env:"QUARANTINE_DIR" default:"quarantine" usage:"directory of the dir store"`
+	EncryptionKey  string `yaml:"encryptionKey" env:"QUARANTINE_ENCRYPTION_KEY" secret:"true" usage:"base64 AES key the entries are encrypted with"`
+	RetentionHours int    `yaml:"retentionHours" env:"QUARANTINE_RETENTION_HOURS" default:"168" usage:"how long entries are kept, 0 means until the size limit"`
+	MaxPayloadKB   int    `yaml:"maxPayloadKB" env:"QUARANTINE_MAX_PAYLOAD_KB" default:"1024" usage:"payloads are truncated to this size, 0 means no limit"`
+	MaxTotalMB     int    `yaml:"maxTotalMB" env:"QUARANTINE_MAX_TOTAL_MB" default:"1024" usage:"maximum total size of the entries, 0 means no limit"`
+	BufferSize     int    `yaml:"bufferSize" env:"QUARANTINE_BUFFER_SIZE" default:"100" usage:"records waiting to be stored"`
+
+	S3 S3Config `yaml:"s3"`
+}
+
+type S3Config struct {
+	Endpoint  string `yaml:"endpoint" env:"QUARANTINE_S3_ENDPOINT" default:"localhost:9000" usage:"endpoint of the s3 store"`
+	UseSSL    bool   `yaml:"useSSL" env:"QUARANTINE_S3_USE_SSL" default:"true" usage:"whether the endpoint uses HTTPS"`
+	Region    string `yaml:"region" env:"QUARANTINE_S3_REGION" usage:"region of the bucket"`
+	Bucket    string `yaml:"bucket" env:"QUARANTINE_S3_BUCKET" default:"cloudsave-quarantine" usage:"bucket of the s3 store"`
+	Prefix    string `yaml:"prefix" env:"QUARANTINE_S3_PREFIX" usage:"prefix of the object names"`
+	AccessKey string `yaml:"accessKey" env:"QUARANTINE_S3_ACCESS_KEY" secret:"true" usage:"access key of the s3 store"`
+	SecretKey string `yaml:"secretKey" env:"QUARANTINE_S3_SECRET_KEY" secret:"true" usage:"secret key of the s3 store"`
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/config"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	t.Setenv(config.FileEnv, writeFile(t, `
+logLevel: debug
+grpc:
+  port: 7000
+metrics:
+  port: 9000
+`))
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("GRPC_PORT", "7100")
+	// empty variables are ignored
+	t.Setenv("METRICS_PORT", "")
+
+	c, err := config.Load([]string{"-grpc.port", "7200"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		setting string
+		got     any
+		want    any
+	}{
+		{"grpc.timeoutSeconds, from the defaults", c.GRPC.TimeoutSeconds, 10},
+		{"metrics.port, from the file", c.Metrics.Port, 9000},
+		{"logLevel, from the environment", c.LogLevel, "warn"},
+		{"grpc.port, from the flags", c.GRPC.Port, 7200},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.setting, tt.got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfigFlagOverridesEnv(t *testing.T) {
+	t.Setenv(config.FileEnv, writeFile(t, "logLevel: debug\n"))
+
+	c, err := config.Load([]string{"-config", writeFile(t, "logLevel: error\n")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.LogLevel != "error" {
+		t.Errorf("logLevel = %q, want the one of the -config file", c.LogLevel)
+	}
+}
+
+func TestLoadRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		env  map[string]string
+		args []string
+		want string
+	}{
+		{name: "unknown key", file: "grpc:\n  prot: 7000\n", want: "prot"},
+		{name: "unknown flag", args: []string{"-grpc.prot", "7000"}, want: "grpc.prot"},
+		{name: "invalid variable", env: map[string]string{"GRPC_PORT": "port"}, want: "GRPC_PORT"},
+		{name: "invalid flag", args: []string{"-capture.sampleRate", "half"}, want: "-capture.sampleRate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.FileEnv, "")
+			if tt.file != "" {
+				t.Setenv(config.FileEnv, writeFile(t, tt.file))
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			_, err := config.Load(tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error about %s, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Setenv(config.FileEnv, "")
+	c, err := config.Load([]string{"-auth.enabled=false"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Validate(); err != nil {
+		t.Fatalf("the defaults must be valid: %v", err)
+	}
+
+	c.Report.MaxViolations = 0
+	c.Admin.Enabled = true
+	c.Capture.Format = "xml"
+	err = c.Validate()
+	if err == nil {
+		t.Fatal("expected errors")
+	}
+	for _, want := range []string{"admin.enabled: requires auth.enabled", "capture.format"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "maxViolations") {
+		t.Errorf("0 violations means no limit, got %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv is the environment variable of the config file, which the -config
+// flag overrides.
+const FileEnv = "CONFIG_FILE"
+
+// setting is a field of Config.
+type setting struct {
+	key    string
+	env    string
+	def    string
+	secret bool
+	usage  string
+	value  reflect.Value
+}
+
+// settings returns the settings of c, in declaration order.
+func (c *Config) settings() []setting {
+	var settings []setting
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			key := prefix + field.Tag.Get("yaml")
+			if field.Type.Kind() == reflect.Struct {
+				walk(v.Field(i), key+".")
+
+				continue
+			}
+			settings = append(settings, setting{
+				key:    key,
+				env:    field.Tag.Get("env"),
+				def:    field.Tag.Get("default"),
+				secret: field.Tag.Get("secret") == "true",
+				usage:  field.Tag.Get("usage"),
+				value:  v.Field(i),
+			})
+		}
+	}
+	walk(reflect.ValueOf(c).Elem(), "")
+
+	return settings
+}
+
+// set parses str into the setting.
+func (s setting) set(str string) error {
+	switch s.value.Kind() {
+	case reflect.String:
+		s.value.SetString(str)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", str)
+		}
+		s.value.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", str)
+		}
+		s.value.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", str)
+		}
+		s.value.SetFloat(f)
+	case reflect.Slice:
+		var list []string
+		for _, item := range strings.Split(str, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		s.value.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("unsupported setting type %s", s.value.Type())
+	}
+
+	return nil
+}
+
+// Load returns the configuration given by the defaults, the config file, the
+// environment variables and the flags in args, in increasing precedence. The
+// config file is the one of the -config flag, else of CONFIG_FILE. Load fails
+// on unknown flags and keys, and on values of the wrong type; the server also
+// calls Validate, the commands only use some of the settings.
+func Load(args []string) (*Config, error) {
+	config := &Config{}
+	settings := config.settings()
+
+	flags := flag.NewFlagSet("service", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	file := flags.String("config", os.Getenv(FileEnv), "YAML config file")
+	flagValues := make(map[string]*string, len(settings))
+	for _, s := range settings {
+		flagValues[s.key] = flags.String(s.key, "", s.usage)
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments %q", flags.Args())
+	}
+	flagSet := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) { flagSet[f.Name] = true })
+
+	for _, s := range settings {
+		if s.def == "" {
+			continue
+		}
+		if err := s.set(s.def); err != nil {
+			return nil, fmt.Errorf("default of %s: %w", s.key, err)
+		}
+	}
+
+	if *file != "" {
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			return nil, err
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err = decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("parse config file %s: %w", *file, err)
+		}
+	}
+
+	var errs []error
+	for _, s := range settings {
+		// empty variables are ignored, as set by docker compose for unset ones
+		if value := os.Getenv(s.env); value != "" && s.env != "" {
+			if err := s.set(value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", s.env, err))
+			}
+		}
+	}
+	for _, s := range settings {
+		if flagSet[s.key] {
+			if err := s.set(*flagValues[s.key]); err != nil {
+				errs = append(errs, fmt.Errorf("-%s: %w", s.key, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return config, nil
+}
+
+// Usage writes the flags, environment variables and defaults of the settings.
+func Usage(w io.Writer) {
+	fmt.Fprintf(w, "  -config file\n    \tYAML config file (env %s)\n", FileEnv)
+	for _, s := range (&Config{}).settings() {
+		fmt.Fprintf(w, "  -%s %s\n    \t%s (env %s", s.key, s.value.Kind(), s.usage, s.env)
+		if s.def != "" {
+			fmt.Fprintf(w, ", default %s", s.def)
+		}
+		fmt.Fprintln(w, ")")
+	}
+}
+
+// Masked returns a copy of c where the secrets that are set are replaced by
+// asterisks.
+func (c *Config) Masked() *Config {
+	masked := *c
+	for _, s := range masked.settings() {
+		if s.secret && s.value.String() != "" {
+			s.value.SetString("********")
+		}
+	}
+
+	return &masked
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/common"
+)
+
+// Validate returns every invalid setting of c.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, key string, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+		}
+	}
+	oneOf := func(key string, value string, values ...string) {
+		check(slices.Contains(values, strings.ToLower(value)), key, "%q is not one of %s", value, strings.Join(values, ", "))
+	}
+	port := func(key string, value int) {
+		check(value > 0 && value < 65536, key, "invalid port %d", value)
+	}
+	positive := func(key string, value int) {
+		check(value > 0, key, "must be greater than 0, got %d", value)
+	}
+	notNegative := func(key string, value int) {
+		check(value >= 0, key, "must not be negative, got %d", value)
+	}
+	fraction := func(key string, value float64) {
+		check(value >= 0 && value <= 1, key, "must be between 0 and 1, got %v", value)
+	}
+
+	oneOf("logLevel", c.LogLevel, "debug", "info", "warn", "error")
+
+	port("grpc.port", c.GRPC.Port)
+	positive("grpc.timeoutSeconds", c.GRPC.TimeoutSeconds)
+	if _, err := common.ParseMethodTimeouts(c.GRPC.MethodTimeouts); err != nil {
+		errs = append(errs, fmt.Errorf("grpc.methodTimeouts: %w", err))
+	}
+	positive("grpc.maxRecvMessageMB", c.GRPC.MaxRecvMessageMB)
+	positive("grpc.maxConcurrentStreams", c.GRPC.MaxConcurrentStreams)
+	positive("grpc.keepaliveTimeSeconds", c.GRPC.KeepaliveTimeSeconds)
+	positive("grpc.keepaliveTimeoutSeconds", c.GRPC.KeepaliveTimeoutSeconds)
+	notNegative("grpc.keepaliveMinTimeSeconds", c.GRPC.KeepaliveMinTimeSeconds)
+	notNegative("grpc.maxConnectionAgeSeconds", c.GRPC.MaxConnectionAgeSeconds)
+	if c.Concurrency.Enabled {
+		oneOf("concurrency.overloadPolicy", c.Concurrency.OverloadPolicy, "reject", "fail-open", "fail-closed")
+		positive("concurrency.minLimit", c.Concurrency.MinLimit)
+		check(c.Concurrency.WriteMaxLimit >= c.Concurrency.MinLimit, "concurrency.writeMaxLimit", "must not be lower than concurrency.minLimit")
+		check(c.Concurrency.ReadMaxLimit >= c.Concurrency.MinLimit, "concurrency.readMaxLimit", "must not be lower than concurrency.minLimit")
+		check(c.Concurrency.BulkMaxLimit >= c.Concurrency.MinLimit, "concurrency.bulkMaxLimit", "must not be lower than concurrency.minLimit")
+		check(c.Concurrency.LatencyTolerance >= 1, "concurrency.latencyTolerance", "must be at least 1, got %v", c.Concurrency.LatencyTolerance)
+	}
+
+	if c.TLS.CertFile != "" {
+		check(c.TLS.KeyFile != "", "tls.keyFile", "required with tls.certFile")
+		oneOf("tls.clientAuth", c.TLS.ClientAuth, "require", "optional")
+		positive("tls.reloadIntervalSeconds", c.TLS.ReloadIntervalSeconds)
+	} else {
+		check(c.TLS.ClientCAFile == "", "tls.clientCAFile", "requires tls.certFile")
+	}
+	check(len(c.TLS.AllowedIdentities) == 0 || c.TLS.ClientCAFile != "", "tls.allowedIdentities", "requires tls.clientCAFile")
+	port("metrics.port", c.Metrics.Port)
+	check(c.Metrics.Port != c.GRPC.Port, "metrics.port", "%d is also the gRPC port", c.Metrics.Port)
+	check(strings.HasPrefix(c.Metrics.Endpoint, "/"), "metrics.endpoint", "%q must start with /", c.Metrics.Endpoint)
+	positive("metrics.maxKeyLabels", c.Metrics.MaxKeyLabels)
+	positive("metrics.readTimeoutSeconds", c.Metrics.ReadTimeoutSeconds)
+	positive("metrics.writeTimeoutSeconds", c.Metrics.WriteTimeoutSeconds)
+	positive("metrics.idleTimeoutSeconds", c.Metrics.IdleTimeoutSeconds)
+
+	if c.Debug.Enabled {
+		check(c.Debug.Address != "", "debug.address", "required when debug is enabled")
+		check((c.Debug.Username == "") == (c.Debug.Password == ""), "debug.password", "username and password must be set together")
+	}
+	notNegative("debug.blockProfileRate", c.Debug.BlockProfileRate)
+	notNegative("debug.mutexProfileFraction", c.Debug.MutexProfileFraction)
+
+	if c.Auth.Enabled {
+		check(c.Auth.BaseURL != "", "auth.baseUrl", "required when auth is enabled")
+		check(c.Auth.Namespace != "", "auth.namespace", "required when auth is enabled")
+	}
+	positive("auth.refreshIntervalSeconds", c.Auth.RefreshIntervalSeconds)
+
+	if c.Admin.Enabled {
+		// without auth anyone reaching the port could turn enforcement off
+		check(c.Auth.Enabled, "admin.enabled", "requires auth.enabled")
+		port("admin.port", c.Admin.Port)
+		check(c.Admin.Port != c.GRPC.Port && c.Admin.Port != c.Metrics.Port, "admin.port", "%d is already used", c.Admin.Port)
+		check(c.Admin.PermissionResource != "", "admin.permissionResource", "required")
+		positive("admin.permissionAction", c.Admin.PermissionAction)
+	}
+
+	positive("health.checkIntervalSeconds", c.Health.CheckIntervalSeconds)
+	notNegative("shutdown.drainSeconds", c.Shutdown.DrainSeconds)
+	positive("shutdown.timeoutSeconds", c.Shutdown.TimeoutSeconds)
+
+	positive("rules.maxEventBannerKB", c.Rules.MaxEventBannerKB)
+	oneOf("validationReport.format", c.Report.Format, "json", "text")
+	notNegative("validationReport.maxViolations", c.Report.MaxViolations)
+	check(c.Locales.Default != "", "locales.default", "required")
+
+	notNegative("payloadLogging.maxBytes", c.PayloadLogging.MaxBytes)
+	fraction("payloadLogging.sampleRate", c.PayloadLogging.SampleRate)
+
+	oneOf("capture.format", c.Capture.Format, "protobuf", "json")
+	fraction("capture.sampleRate", c.Capture.SampleRate)
+	notNegative("capture.maxSizeMB", c.Capture.MaxSizeMB)
+	positive("capture.bufferSize", c.Capture.BufferSize)
+
+	oneOf("audit.sink", c.Audit.Sink, "none", "stdout", "file", "webhook")
+	positive("audit.bufferSize", c.Audit.BufferSize)
+	if strings.EqualFold(c.Audit.Sink, "webhook") {
+		check(c.Audit.WebhookURL != "", "audit.webhookUrl", "required by the webhook sink")
+		positive("audit.webhookTimeoutSeconds", c.Audit.WebhookTimeoutSeconds)
+	}
+	if strings.EqualFold(c.Audit.Sink, "file") {
+		check(c.Audit.FilePath != "", "audit.filePath", "required by the file sink")
+		notNegative("audit.fileMaxSizeMB", c.Audit.FileMaxSizeMB)
+		notNegative("audit.fileMaxBackups", c.Audit.FileMaxBackups)
+	}
+
+	oneOf("quarantine.store", c.Quarantine.Store, "none", "dir", "s3")
+	notNegative("quarantine.retentionHours", c.Quarantine.RetentionHours)
+	notNegative("quarantine.maxPayloadKB", c.Quarantine.MaxPayloadKB)
+	notNegative("quarantine.maxTotalMB", c.Quarantine.MaxTotalMB)
+	positive("quarantine.bufferSize", c.Quarantine.BufferSize)
+	if strings.EqualFold(c.Quarantine.Store, "s3") {
+		check(c.Quarantine.S3.Endpoint != "", "quarantine.s3.endpoint", "required by the s3 store")
+		check(c.Quarantine.S3.Bucket != "", "quarantine.s3.bucket", "required by the s3 store")
+	}
+
+	return errors.Join(errs...)
+}
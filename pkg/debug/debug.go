@@ -0,0 +1,65 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+// Package debug serves the pprof profiles on a listener of their own, apart
+// from the metrics and health endpoints.
+package debug
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+)
+
+// Config configures the debug handler and the profiling rates.
+type Config struct {
+	// Username and Password protect the handler with basic auth when set.
+	Username string
+	Password string
+	// BlockProfileRate and MutexProfileFraction are given to
+	// runtime.SetBlockProfileRate and runtime.SetMutexProfileFraction, 0
+	// disables the block and mutex profiles.
+	BlockProfileRate     int
+	MutexProfileFraction int
+}
+
+// NewHandler sets the profiling rates of cfg and returns the pprof handler,
+// serving under /debug/pprof/.
+func NewHandler(cfg Config) http.Handler {
+	runtime.SetBlockProfileRate(cfg.BlockProfileRate)
+	runtime.SetMutexProfileFraction(cfg.MutexProfileFraction)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	if cfg.Username == "" && cfg.Password == "" {
+		return mux
+	}
+
+	return basicAuth(mux, cfg.Username, cfg.Password)
+}
+
+func basicAuth(next http.Handler, username, password string) http.Handler {
+	// hashed so the comparisons take the same time whatever the lengths
+	wantUser, wantPassword := sha256.Sum256([]byte(username)), sha256.Sum256([]byte(password))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		gotUser, gotPassword := sha256.Sum256([]byte(user)), sha256.Sum256([]byte(pass))
+		if !ok ||
+			subtle.ConstantTimeCompare(gotUser[:], wantUser[:]) != 1 ||
+			subtle.ConstantTimeCompare(gotPassword[:], wantPassword[:]) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="debug"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
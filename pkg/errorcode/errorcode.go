@@ -0,0 +1,214 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+// Package errorcode is the catalog of error codes returned to CloudSave in
+// pb.Error. Codes are stable: once published a code keeps its meaning.
+package errorcode
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Category string
+
+const (
+	CategorySchema    Category = "schema"
+	CategoryPolicy    Category = "policy"
+	CategoryRateLimit Category = "rate-limit"
+	CategoryAntiCheat Category = "anti-cheat"
+	CategoryInternal  Category = "internal"
+)
+
+// Entry describes an error code. Message is the default message template,
+// where {name} is replaced by the parameter of the same name.
+type Entry struct {
+	Code        int32    `json:"code"`
+	Name        string   `json:"name"`
+	Category    Category `json:"category"`
+	Message     string   `json:"message"`
+	Params      []string `json:"params,omitempty"`
+	Description string   `json:"description"`
+}
+
+var catalog = map[int32]Entry{}
+
+func register(e Entry) Entry {
+	if _, found := catalog[e.Code]; found {
+		panic(fmt.Sprintf("error code %d is registered more than once", e.Code))
+	}
+	catalog[e.Code] = e
+
+	return e
+}
+
+var (
+	SchemaInvalid = register(Entry{
+		Code:        1,
+		Name:        "SCHEMA_INVALID",
+		Category:    CategorySchema,
+		Message:     "{detail}",
+		Params:      []string{"key", "detail"},
+		Description: "The payload does not match the schema of the record key.",
+	})
+	NotYetAvailable = register(Entry{
+		Code:        2,
+		Name:        "NOT_YET_AVAILABLE",
+		Category:    CategoryPolicy,
+		Message:     "not accessible yet",
+		Params:      []string{"key", "availableOn"},
+		Description: "The record cannot be read before its availability time.",
+	})
+	PayloadTooLarge = register(Entry{
+		Code:        3,
+		Name:        "PAYLOAD_TOO_LARGE",
+		Category:    CategoryPolicy,
+		Message:     "maximum size for {key} is {limit} kB",
+		Params:      []string{"key", "limit", "size"},
+		Description: "The binary is larger than allowed for the record key.",
+	})
+	RecordImmutable = register(Entry{
+		Code:        4,
+		Name:        "RECORD_IMMUTABLE",
+		Category:    CategoryAntiCheat,
+		Message:     "{key} can only be created once",
+		Params:      []string{"key", "version"},
+		Description: "The record can only be written once and already exists.",
+	})
+	NotReady = register(Entry{
+		Code:        5,
+		Name:        "NOT_READY",
+		Category:    CategoryPolicy,
+		Message:     "today's {key} is not ready yet",
+		Params:      []string{"key", "updatedAt"},
+		Description: "The record has not been updated for the current day yet.",
+	})
+	NoLongerAvailable = register(Entry{
+		Code:        6,
+		Name:        "NO_LONGER_AVAILABLE",
+		Category:    CategoryPolicy,
+		Message:     "{key} is no longer available",
+		Params:      []string{"key", "availableUntil"},
+		Description: "The record cannot be accessed after the end of its availability.",
+	})
+	OutsideSchedule = register(Entry{
+		Code:        7,
+		Name:        "OUTSIDE_SCHEDULE",
+		Category:    CategoryPolicy,
+		Message:     "{key} is not available at this time",
+		Params:      []string{"key"},
+		Description: "The record can only be accessed on some days or during recurring windows.",
+	})
+	Expired = register(Entry{
+		Code:        8,
+		Name:        "EXPIRED",
+		Category:    CategoryPolicy,
+		Message:     "{key} has expired",
+		Params:      []string{"key", "expiresAt"},
+		Description: "The record is past the expiry time of its TTL configuration.",
+	})
+	TTLRequired = register(Entry{
+		Code:        9,
+		Name:        "TTL_REQUIRED",
+		Category:    CategoryPolicy,
+		Message:     "{key} requires a ttl",
+		Params:      []string{"key"},
+		Description: "The TTL policy of the record key requires a TTL configuration.",
+	})
+	TTLForbidden = register(Entry{
+		Code:        10,
+		Name:        "TTL_FORBIDDEN",
+		Category:    CategoryPolicy,
+		Message:     "{key} cannot have a ttl",
+		Params:      []string{"key"},
+		Description: "The TTL policy of the record key forbids a TTL configuration.",
+	})
+	TTLBeforeCreation = register(Entry{
+		Code:        11,
+		Name:        "TTL_BEFORE_CREATION",
+		Category:    CategoryPolicy,
+		Message:     "the ttl of {key} expires before the record is created",
+		Params:      []string{"key", "expiresAt", "createdAt"},
+		Description: "The TTL configuration expires at or before the creation of the record.",
+	})
+	TTLTooShort = register(Entry{
+		Code:        12,
+		Name:        "TTL_TOO_SHORT",
+		Category:    CategoryPolicy,
+		Message:     "the ttl of {key} must be at least {limit}",
+		Params:      []string{"key", "limit", "lifetime"},
+		Description: "The lifetime of the record is shorter than its TTL policy allows.",
+	})
+	TTLTooLong = register(Entry{
+		Code:        13,
+		Name:        "TTL_TOO_LONG",
+		Category:    CategoryPolicy,
+		Message:     "the ttl of {key} must be at most {limit}",
+		Params:      []string{"key", "limit", "lifetime"},
+		Description: "The lifetime of the record is longer than its TTL policy allows.",
+	})
+	TTLActionNotAllowed = register(Entry{
+		Code:        14,
+		Name:        "TTL_ACTION_NOT_ALLOWED",
+		Category:    CategoryPolicy,
+		Message:     "the ttl action of {key} must be one of {actions}",
+		Params:      []string{"key", "action", "actions"},
+		Description: "The TTL action of the record is not allowed by its TTL policy.",
+	})
+	RateLimited = register(Entry{
+		Code:        429,
+		Name:        "RATE_LIMITED",
+		Category:    CategoryRateLimit,
+		Message:     "too many requests, retry later",
+		Params:      []string{"key"},
+		Description: "The validator is overloaded and the fail-closed overload policy applies.",
+	})
+	Internal = register(Entry{
+		Code:        500,
+		Name:        "INTERNAL",
+		Category:    CategoryInternal,
+		Message:     "record could not be validated",
+		Params:      []string{"key", "rule"},
+		Description: "A rule could not be evaluated and the fail-closed error policy applies.",
+	})
+)
+
+// Lookup returns the entry registered for code.
+func Lookup(code int32) (Entry, bool) {
+	e, found := catalog[code]
+
+	return e, found
+}
+
+// All returns every entry of the catalog ordered by code.
+func All() []Entry {
+	entries := make([]Entry, 0, len(catalog))
+	for _, e := range catalog {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Code < entries[j].Code })
+
+	return entries
+}
+
+// Format renders the message template of the entry with params.
+func (e Entry) Format(params map[string]any) string {
+	return Interpolate(e.Message, params)
+}
+
+// Interpolate replaces each {name} in template with params[name]. Unknown
+// placeholders are left untouched.
+func Interpolate(template string, params map[string]any) string {
+	if len(params) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+
+	replacements := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		replacements = append(replacements, "{"+name+"}", fmt.Sprint(value))
+	}
+
+	return strings.NewReplacer(replacements...).Replace(template)
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+// Package healthcheck reports whether the service can handle calls, from the
+// state of its dependencies, on the gRPC health service and over HTTP.
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Check is a dependency of the service.
+type Check struct {
+	Name string
+	// Critical checks make the service not ready when they fail, the other
+	// checks are only reported.
+	Critical bool
+	Run      func(ctx context.Context) error
+}
+
+// Result is the result of the last run of a check.
+type Result struct {
+	Name      string    `json:"name"`
+	Critical  bool      `json:"critical"`
+	Healthy   bool      `json:"healthy"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// Report is the state of the service, as returned by /readyz.
+type Report struct {
+	Ready  bool     `json:"ready"`
+	Checks []Result `json:"checks"`
+}
+
+// Checker runs the checks periodically and sets the status of the services
+// on the gRPC health server: SERVING when every critical check passes,
+// NOT_SERVING otherwise.
+type Checker struct {
+	health   *health.Server
+	services []string
+	interval time.Duration
+	checks   []Check
+
+	mu       sync.RWMutex
+	report   Report
+	shutdown bool
+}
+
+// NewChecker sets the status of services, and of the server as a whole, on
+// healthServer every interval.
+func NewChecker(healthServer *health.Server, interval time.Duration, services ...string) *Checker {
+	return &Checker{
+		health:   healthServer,
+		services: append([]string{""}, services...),
+		interval: interval,
+	}
+}
+
+// Add adds a check, it must be called before Start.
+func (c *Checker) Add(check Check) {
+	c.checks = append(c.checks, check)
+}
+
+// Start runs the checks, then keeps running them in the background until ctx
+// is done.
+func (c *Checker) Start(ctx context.Context) {
+	c.run(ctx)
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.run(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (c *Checker) run(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, c.interval)
+	defer cancel()
+
+	report := Report{Ready: true}
+	for _, check := range c.checks {
+		result := Result{Name: check.Name, Critical: check.Critical, Healthy: true, CheckedAt: time.Now()}
+		if err := check.Run(ctx); err != nil {
+			result.Healthy = false
+			result.Error = err.Error()
+			if check.Critical {
+				report.Ready = false
+			}
+		}
+		report.Checks = append(report.Checks, result)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.shutdown {
+		return
+	}
+	c.report = report
+
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	if !report.Ready {
+		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	for _, service := range c.services {
+		c.health.SetServingStatus(service, status)
+	}
+}
+
+// Report returns the result of the last run of the checks.
+func (c *Checker) Report() Report {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.report
+}
+
+// Shutdown sets every service to NOT_SERVING for good, so the service stops
+// receiving new calls before it stops.
+func (c *Checker) Shutdown() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.shutdown = true
+	c.report.Ready = false
+	c.health.Shutdown()
+}
+
+// LivenessHandler serves /healthz, it answers as long as the process runs:
+// restarting does not fix an unavailable dependency.
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = fmt.Fprintln(w, "ok")
+	})
+}
+
+// ReadinessHandler serves /readyz, the report of the last run of the checks,
+// with status 503 when the service is not ready.
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		report := c.Report()
+		w.Header().Set("Content-Type", "application/json")
+		if !report.Ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
+
+// BufferCheck fails when more than threshold, a fraction, of a buffer is in
+// use, before the items added start to be dropped.
+func BufferCheck(buffered func() (int, int), threshold float64) func(ctx context.Context) error {
+	return func(_ context.Context) error {
+		n, size := buffered()
+		if size > 0 && float64(n) > threshold*float64(size) {
+			return fmt.Errorf("buffer is %d%% full, %d of %d", n*100/size, n, size)
+		}
+
+		return nil
+	}
+}
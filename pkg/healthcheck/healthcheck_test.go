@@ -0,0 +1,111 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const service = "test.Service"
+
+func servingStatus(t *testing.T, healthServer *health.Server, service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	t.Helper()
+
+	response, err := healthServer.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return response.GetStatus()
+}
+
+func readyzStatus(c *Checker) int {
+	recorder := httptest.NewRecorder()
+	c.ReadinessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	return recorder.Code
+}
+
+// toggle is a check failing while err is set.
+type toggle struct{ err error }
+
+func (c *toggle) run(_ context.Context) error { return c.err }
+
+func TestCriticalCheckTransitions(t *testing.T) {
+	healthServer := health.NewServer()
+	checker := NewChecker(healthServer, time.Minute, service)
+	critical := &toggle{}
+	checker.Add(Check{Name: "critical", Critical: true, Run: critical.run})
+
+	steps := []struct {
+		err    error
+		status grpc_health_v1.HealthCheckResponse_ServingStatus
+		code   int
+	}{
+		{nil, grpc_health_v1.HealthCheckResponse_SERVING, http.StatusOK},
+		{errors.New("down"), grpc_health_v1.HealthCheckResponse_NOT_SERVING, http.StatusServiceUnavailable},
+		{nil, grpc_health_v1.HealthCheckResponse_SERVING, http.StatusOK},
+	}
+	for i, step := range steps {
+		critical.err = step.err
+		checker.run(context.Background())
+
+		for _, name := range []string{"", service} {
+			if got := servingStatus(t, healthServer, name); got != step.status {
+				t.Errorf("step %d: status of %q = %s, want %s", i, name, got, step.status)
+			}
+		}
+		if got := readyzStatus(checker); got != step.code {
+			t.Errorf("step %d: /readyz status = %d, want %d", i, got, step.code)
+		}
+	}
+}
+
+func TestNonCriticalCheckIsOnlyReported(t *testing.T) {
+	healthServer := health.NewServer()
+	checker := NewChecker(healthServer, time.Minute, service)
+	checker.Add(Check{Name: "critical", Critical: true, Run: (&toggle{}).run})
+	checker.Add(Check{Name: "reported", Run: (&toggle{err: errors.New("full")}).run})
+	checker.run(context.Background())
+
+	if got := servingStatus(t, healthServer, service); got != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("status = %s, want SERVING", got)
+	}
+	report := checker.Report()
+	if !report.Ready || len(report.Checks) != 2 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if result := report.Checks[1]; result.Healthy || result.Critical || result.Error != "full" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if got := readyzStatus(checker); got != http.StatusOK {
+		t.Errorf("/readyz status = %d, want %d", got, http.StatusOK)
+	}
+}
+
+func TestShutdownIsFinal(t *testing.T) {
+	healthServer := health.NewServer()
+	checker := NewChecker(healthServer, time.Minute, service)
+	checker.Add(Check{Name: "critical", Critical: true, Run: (&toggle{}).run})
+	checker.run(context.Background())
+	checker.Shutdown()
+	// a run after the shutdown must not make the service serve again
+	checker.run(context.Background())
+
+	if got := servingStatus(t, healthServer, service); got != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("status = %s, want NOT_SERVING", got)
+	}
+	if got := readyzStatus(checker); got != http.StatusServiceUnavailable {
+		t.Errorf("/readyz status = %d, want %d", got, http.StatusServiceUnavailable)
+	}
+}
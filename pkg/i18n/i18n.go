@@ -0,0 +1,121 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+// Package i18n provides translated message templates loaded from bundle
+// files. A bundle is a YAML or JSON file named after its locale, e.g. ja.yaml
+// or pt-BR.json, that maps message keys to templates.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Translator looks up message templates by locale and key.
+type Translator struct {
+	defaultLocale string
+	bundles       map[string]map[string]string
+}
+
+// NewTranslator returns a Translator without bundles, which only resolves
+// locales.
+func NewTranslator(defaultLocale string) *Translator {
+	return &Translator{
+		defaultLocale: Normalize(defaultLocale),
+		bundles:       map[string]map[string]string{},
+	}
+}
+
+// LoadDir loads every *.yaml, *.yml and *.json bundle in dir.
+func LoadDir(dir string, defaultLocale string) (*Translator, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	t := NewTranslator(defaultLocale)
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var bundle map[string]string
+		if err = yaml.Unmarshal(data, &bundle); err != nil {
+			return nil, fmt.Errorf("parse bundle %s: %w", entry.Name(), err)
+		}
+		t.bundles[Normalize(strings.TrimSuffix(entry.Name(), ext))] = bundle
+	}
+
+	return t, nil
+}
+
+// DefaultLocale is the locale used when none is requested.
+func (t *Translator) DefaultLocale() string {
+	return t.defaultLocale
+}
+
+// Locales returns the locales that have a bundle.
+func (t *Translator) Locales() []string {
+	locales := make([]string, 0, len(t.bundles))
+	for locale := range t.bundles {
+		locales = append(locales, locale)
+	}
+
+	return locales
+}
+
+// Lookup returns the template of key for locale, falling back to the base
+// language of locale (pt for pt-br) and then to the default locale.
+func (t *Translator) Lookup(locale string, key string) (string, bool) {
+	for _, l := range t.candidates(locale) {
+		if template, found := t.bundles[l][key]; found {
+			return template, true
+		}
+	}
+
+	return "", false
+}
+
+func (t *Translator) candidates(locale string) []string {
+	locale = Normalize(locale)
+
+	var candidates []string
+	if locale != "" {
+		candidates = append(candidates, locale)
+		if base, _, found := strings.Cut(locale, "-"); found {
+			candidates = append(candidates, base)
+		}
+	}
+
+	return append(candidates, t.defaultLocale)
+}
+
+// Normalize lowercases locale and uses '-' as separator, so en_US and en-us
+// are the same locale.
+func Normalize(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// ParseAcceptLanguage returns the first language of an Accept-Language
+// header value, ignoring quality values.
+func ParseAcceptLanguage(value string) string {
+	first, _, _ := strings.Cut(value, ",")
+	tag, _, _ := strings.Cut(first, ";")
+	tag = strings.TrimSpace(tag)
+	if tag == "*" {
+		return ""
+	}
+
+	return tag
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+// Package lifecycle shuts the service down in order, so rolling deploys do not
+// drop the calls in flight.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Manager runs the shutdown steps in the order they were added.
+type Manager struct {
+	logger *slog.Logger
+	steps  []step
+}
+
+type step struct {
+	name    string
+	timeout time.Duration
+	fn      func(ctx context.Context) error
+}
+
+func NewManager(logger *slog.Logger) *Manager {
+	return &Manager{logger: logger}
+}
+
+// OnShutdown adds a step to the shutdown. fn is given a context that expires
+// after timeout, or never when timeout is 0.
+func (m *Manager) OnShutdown(name string, timeout time.Duration, fn func(ctx context.Context) error) {
+	m.steps = append(m.steps, step{name: name, timeout: timeout, fn: fn})
+}
+
+// Shutdown runs every step, even when a previous one failed, and returns the
+// errors of the steps that failed.
+func (m *Manager) Shutdown() error {
+	var errs []error
+	for _, s := range m.steps {
+		ctx, cancel := s.context()
+		start := time.Now()
+		err := s.fn(ctx)
+		cancel()
+		if err != nil {
+			m.logger.Error("shutdown step failed", "step", s.name, "duration", time.Since(start).String(), "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+
+			continue
+		}
+		m.logger.Info("shutdown step done", "step", s.name, "duration", time.Since(start).String())
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s step) context() (context.Context, context.CancelFunc) {
+	if s.timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+
+	return context.WithTimeout(context.Background(), s.timeout)
+}
+
+// Wait waits for d, e.g. for load balancers to stop sending new calls.
+func Wait(d time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		select {
+		case <-time.After(d):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// StopGRPCServer stops srv gracefully, waiting for the calls in flight, and
+// stops it forcibly, cancelling them, when the context expires.
+func StopGRPCServer(srv *grpc.Server) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		stopped := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+			return nil
+		case <-ctx.Done():
+			srv.Stop()
+
+			return fmt.Errorf("calls still in flight were cancelled: %w", ctx.Err())
+		}
+	}
+}
+
+// StopHTTPServer stops srv gracefully, and closes its remaining connections
+// when the context expires.
+func StopHTTPServer(srv *http.Server) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if err := srv.Shutdown(ctx); err != nil {
+			_ = srv.Close()
+
+			return err
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package limiter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Set holds a Limiter per class of methods, and collects their metrics.
+type Set struct {
+	limiters map[string]*Limiter
+
+	limitDesc    *prometheus.Desc
+	inflightDesc *prometheus.Desc
+	rejected     *prometheus.CounterVec
+}
+
+// NewSet returns the set of limiters, by class.
+func NewSet(limiters map[string]*Limiter) *Set {
+	return &Set{
+		limiters: limiters,
+		limitDesc: prometheus.NewDesc("cloudsave_validator_concurrency_limit",
+			"Current concurrency limit of a class of methods.", []string{"class"}, nil),
+		inflightDesc: prometheus.NewDesc("cloudsave_validator_concurrency_inflight",
+			"Calls in flight of a class of methods.", []string{"class"}, nil),
+		rejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "cloudsave_validator",
+			Name:      "concurrency_rejected_total",
+			Help:      "Number of calls not handled because the concurrency limit of their class was reached.",
+		}, []string{"class"}),
+	}
+}
+
+// OverloadFunc returns the response of a call not handled because the limit
+// of its class was reached.
+type OverloadFunc func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo) (interface{}, error)
+
+// Rejected fails the calls not handled with RESOURCE_EXHAUSTED.
+func Rejected(context.Context, interface{}, *grpc.UnaryServerInfo) (interface{}, error) {
+	return nil, status.Error(codes.ResourceExhausted, "too many calls in flight, retry later")
+}
+
+// UnaryServerInterceptor limits the calls of the methods classify returns a
+// class of, the other methods are not limited. The calls over the limit are
+// answered by overloaded.
+func (s *Set) UnaryServerInterceptor(classify func(fullMethod string) (string, bool), overloaded OverloadFunc) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		class, found := classify(info.FullMethod)
+		limiter := s.limiters[class]
+		if !found || limiter == nil {
+			return handler(ctx, req)
+		}
+
+		release, ok := limiter.Acquire()
+		if !ok {
+			s.rejected.WithLabelValues(class).Inc()
+
+			return overloaded(ctx, req, info)
+		}
+		var dropped bool
+		defer func() { release(dropped) }()
+
+		resp, err := handler(ctx, req)
+		dropped = errors.Is(ctx.Err(), context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded
+
+		return resp, err
+	}
+}
+
+func (s *Set) Describe(ch chan<- *prometheus.Desc) {
+	ch <- s.limitDesc
+	ch <- s.inflightDesc
+	s.rejected.Describe(ch)
+}
+
+func (s *Set) Collect(ch chan<- prometheus.Metric) {
+	for class, limiter := range s.limiters {
+		ch <- prometheus.MustNewConstMetric(s.limitDesc, prometheus.GaugeValue, float64(limiter.Limit()), class)
+		ch <- prometheus.MustNewConstMetric(s.inflightDesc, prometheus.GaugeValue, float64(limiter.Inflight()), class)
+	}
+	s.rejected.Collect(ch)
+}
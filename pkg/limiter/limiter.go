@@ -0,0 +1,116 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+// Package limiter sheds load with concurrency limits that adapt to the
+// latency of the calls, so a burst is rejected early instead of piling up
+// calls in memory until the process is killed.
+package limiter
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Config bounds a Limiter.
+type Config struct {
+	MinLimit int
+	MaxLimit int
+	// LatencyTolerance is how much slower than usual the recent calls may
+	// get, e.g. 1.5, before the limit is lowered.
+	LatencyTolerance float64
+}
+
+// Limiter limits the calls in flight. The limit grows while the calls keep
+// their usual latency and shrinks when they slow down or time out, in the
+// way of the gradient algorithm of Netflix's concurrency-limits.
+type Limiter struct {
+	config Config
+
+	mu           sync.Mutex
+	limit        float64
+	inflight     int
+	shortLatency float64
+	longLatency  float64
+}
+
+const (
+	shortAlpha = 0.1
+	longAlpha  = 0.01
+	smoothing  = 0.2
+)
+
+// New returns a Limiter starting halfway between the bounds of config.
+func New(config Config) *Limiter {
+	return &Limiter{
+		config: config,
+		limit:  float64(config.MinLimit+config.MaxLimit) / 2,
+	}
+}
+
+// Acquire reserves a slot for a call, or returns false when the limit is
+// reached. The call must then release the slot, reporting whether it was
+// dropped, i.e. timed out.
+func (l *Limiter) Acquire() (release func(dropped bool), ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inflight >= int(l.limit) {
+		return nil, false
+	}
+	l.inflight++
+	inflight := l.inflight
+	start := time.Now()
+
+	return func(dropped bool) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.inflight--
+		l.update(time.Since(start), inflight, dropped)
+	}, true
+}
+
+// Limit returns the current limit.
+func (l *Limiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return int(l.limit)
+}
+
+// Inflight returns the number of calls in flight.
+func (l *Limiter) Inflight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.inflight
+}
+
+// update adjusts the limit from the latency of a call which had inflight
+// calls in flight when it started.
+func (l *Limiter) update(latency time.Duration, inflight int, dropped bool) {
+	sample := float64(latency)
+	if l.longLatency == 0 {
+		l.shortLatency, l.longLatency = sample, sample
+	}
+	l.shortLatency += shortAlpha * (sample - l.shortLatency)
+	l.longLatency += longAlpha * (sample - l.longLatency)
+	// the usual latency follows a lasting improvement quickly
+	if l.longLatency > 2*l.shortLatency {
+		l.longLatency *= 0.95
+	}
+
+	gradient := math.Max(0.5, math.Min(1, l.config.LatencyTolerance*l.longLatency/l.shortLatency))
+	if dropped {
+		gradient = 0.5
+	}
+	// the limit only grows when it is what holds the calls back
+	if gradient == 1 && inflight < int(l.limit)/2 {
+		return
+	}
+
+	newLimit := l.limit*gradient + math.Sqrt(l.limit)
+	l.limit = l.limit*(1-smoothing) + newLimit*smoothing
+	l.limit = math.Max(float64(l.config.MinLimit), math.Min(float64(l.config.MaxLimit), l.limit))
+}
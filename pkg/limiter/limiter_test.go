@@ -0,0 +1,140 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var config = Config{MinLimit: 10, MaxLimit: 100, LatencyTolerance: 1.5}
+
+func TestAcquire(t *testing.T) {
+	l := New(Config{MinLimit: 2, MaxLimit: 2, LatencyTolerance: 1.5})
+
+	first, ok := l.Acquire()
+	if !ok {
+		t.Fatal("first call rejected")
+	}
+	if _, ok = l.Acquire(); !ok {
+		t.Fatal("second call rejected")
+	}
+	if _, ok = l.Acquire(); ok {
+		t.Fatal("third call accepted over the limit of 2")
+	}
+
+	first(false)
+	if l.Inflight() != 1 {
+		t.Errorf("inflight = %d, want 1", l.Inflight())
+	}
+	if _, ok = l.Acquire(); !ok {
+		t.Error("call rejected after a release")
+	}
+}
+
+func TestLimitShrinksOnDrops(t *testing.T) {
+	l := New(config)
+	previous := l.Limit()
+	for i := 0; i < 50; i++ {
+		l.update(10*time.Millisecond, l.Limit(), true)
+		if l.Limit() > previous {
+			t.Fatalf("limit grew from %d to %d on a drop", previous, l.Limit())
+		}
+		previous = l.Limit()
+	}
+	if l.Limit() != config.MinLimit {
+		t.Errorf("limit = %d after 50 drops, want the minimum %d", l.Limit(), config.MinLimit)
+	}
+}
+
+func TestLimitShrinksWhenCallsSlowDown(t *testing.T) {
+	l := New(config)
+	for i := 0; i < 100; i++ {
+		l.update(10*time.Millisecond, l.Limit(), false)
+	}
+	steady := l.Limit()
+
+	for i := 0; i < 20; i++ {
+		l.update(100*time.Millisecond, l.Limit(), false)
+	}
+	if l.Limit() >= steady {
+		t.Errorf("limit = %d after the calls slowed down, was %d", l.Limit(), steady)
+	}
+}
+
+func TestLimitGrows(t *testing.T) {
+	l := New(config)
+	start := l.Limit()
+
+	// with few calls in flight, the limit is not what holds them back
+	for i := 0; i < 20; i++ {
+		l.update(10*time.Millisecond, 1, false)
+	}
+	if l.Limit() != start {
+		t.Errorf("limit = %d with few calls in flight, want %d", l.Limit(), start)
+	}
+
+	for i := 0; i < 200; i++ {
+		l.update(10*time.Millisecond, l.Limit(), false)
+	}
+	if l.Limit() != config.MaxLimit {
+		t.Errorf("limit = %d with steady latencies, want the maximum %d", l.Limit(), config.MaxLimit)
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	l := New(Config{MinLimit: 1, MaxLimit: 20, LatencyTolerance: 1.5})
+	set := NewSet(map[string]*Limiter{"write": l})
+	classify := func(fullMethod string) (string, bool) {
+		return "write", fullMethod == "/svc/Write"
+	}
+	overloaded := func(context.Context, interface{}, *grpc.UnaryServerInfo) (interface{}, error) {
+		return "overloaded", nil
+	}
+	interceptor := set.UnaryServerInterceptor(classify, overloaded)
+	write := &grpc.UnaryServerInfo{FullMethod: "/svc/Write"}
+
+	// a call in flight holds its slot until it returns
+	var nested interface{}
+	_, _ = interceptor(context.Background(), nil, write, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		for l.Inflight() < l.Limit() {
+			release, _ := l.Acquire()
+			defer release(false)
+		}
+		nested, _ = interceptor(ctx, nil, write, func(context.Context, interface{}) (interface{}, error) {
+			return "handled", nil
+		})
+		unlimited, _ := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Read"}, func(context.Context, interface{}) (interface{}, error) {
+			return "handled", nil
+		})
+		if unlimited != "handled" {
+			t.Errorf("unclassified method answered %v", unlimited)
+		}
+
+		return nil, nil
+	})
+	if nested != "overloaded" {
+		t.Errorf("call over the limit answered %v", nested)
+	}
+
+	before := l.limit
+	_, err := interceptor(context.Background(), nil, write, func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.DeadlineExceeded, "timeout")
+	})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("error = %v, want the error of the handler", err)
+	}
+	if l.limit >= before {
+		t.Errorf("limit = %v after a timed out call, was %v", l.limit, before)
+	}
+	if l.Inflight() != 0 {
+		t.Errorf("inflight = %d after the calls returned, want 0", l.Inflight())
+	}
+}
@@ -0,0 +1,1089 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v3.21.9
+// source: admin.proto
+
+package validator
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Rule struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Name       string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version    string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	KeyPattern string                 `protobuf:"bytes,3,opt,name=keyPattern,proto3" json:"keyPattern,omitempty"`
+	Hooks      []string               `protobuf:"bytes,4,rep,name=hooks,proto3" json:"hooks,omitempty"`
+	// enforce, shadow or disabled
+	Mode          string `protobuf:"bytes,5,opt,name=mode,proto3" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rule) Reset() {
+	*x = Rule{}
+	mi := &file_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rule) ProtoMessage() {}
+
+func (x *Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rule.ProtoReflect.Descriptor instead.
+func (*Rule) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Rule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Rule) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Rule) GetKeyPattern() string {
+	if x != nil {
+		return x.KeyPattern
+	}
+	return ""
+}
+
+func (x *Rule) GetHooks() []string {
+	if x != nil {
+		return x.Hooks
+	}
+	return nil
+}
+
+func (x *Rule) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+type ListRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRulesRequest) Reset() {
+	*x = ListRulesRequest{}
+	mi := &file_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRulesRequest) ProtoMessage() {}
+
+func (x *ListRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListRulesRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{1}
+}
+
+type ListRulesResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RuleSetVersion string                 `protobuf:"bytes,1,opt,name=ruleSetVersion,proto3" json:"ruleSetVersion,omitempty"`
+	Rules          []*Rule                `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListRulesResponse) Reset() {
+	*x = ListRulesResponse{}
+	mi := &file_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRulesResponse) ProtoMessage() {}
+
+func (x *ListRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListRulesResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListRulesResponse) GetRuleSetVersion() string {
+	if x != nil {
+		return x.RuleSetVersion
+	}
+	return ""
+}
+
+func (x *ListRulesResponse) GetRules() []*Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type GetConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigRequest) Reset() {
+	*x = GetConfigRequest{}
+	mi := &file_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRequest) ProtoMessage() {}
+
+func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{3}
+}
+
+type GetConfigResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RuleSetVersion string                 `protobuf:"bytes,1,opt,name=ruleSetVersion,proto3" json:"ruleSetVersion,omitempty"`
+	// SHA-256 of the active rule set configuration
+	ConfigHash string `protobuf:"bytes,2,opt,name=configHash,proto3" json:"configHash,omitempty"`
+	// active rule set configuration, as JSON
+	RuleSetConfig string `protobuf:"bytes,3,opt,name=ruleSetConfig,proto3" json:"ruleSetConfig,omitempty"`
+	RuleSetFile   string `protobuf:"bytes,4,opt,name=ruleSetFile,proto3" json:"ruleSetFile,omitempty"`
+	ShadowMode    bool   `protobuf:"varint,5,opt,name=shadowMode,proto3" json:"shadowMode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigResponse) Reset() {
+	*x = GetConfigResponse{}
+	mi := &file_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigResponse) ProtoMessage() {}
+
+func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetConfigResponse) GetRuleSetVersion() string {
+	if x != nil {
+		return x.RuleSetVersion
+	}
+	return ""
+}
+
+func (x *GetConfigResponse) GetConfigHash() string {
+	if x != nil {
+		return x.ConfigHash
+	}
+	return ""
+}
+
+func (x *GetConfigResponse) GetRuleSetConfig() string {
+	if x != nil {
+		return x.RuleSetConfig
+	}
+	return ""
+}
+
+func (x *GetConfigResponse) GetRuleSetFile() string {
+	if x != nil {
+		return x.RuleSetFile
+	}
+	return ""
+}
+
+func (x *GetConfigResponse) GetShadowMode() bool {
+	if x != nil {
+		return x.ShadowMode
+	}
+	return false
+}
+
+type EvaluateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name of a CloudsaveValidatorService method, e.g. BeforeWriteGameRecord
+	Hook string `protobuf:"bytes,1,opt,name=hook,proto3" json:"hook,omitempty"`
+	// JSON encoding of the record of the request, bulk hooks receive the record alone
+	RecordJson    string `protobuf:"bytes,2,opt,name=recordJson,proto3" json:"recordJson,omitempty"`
+	Locale        string `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateRequest) Reset() {
+	*x = EvaluateRequest{}
+	mi := &file_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateRequest) ProtoMessage() {}
+
+func (x *EvaluateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EvaluateRequest) GetHook() string {
+	if x != nil {
+		return x.Hook
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetRecordJson() string {
+	if x != nil {
+		return x.RecordJson
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type RuleEvaluation struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Rule    string                 `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Version string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Mode    string                 `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	// whether the rule was evaluated in shadow mode
+	Shadow bool `protobuf:"varint,4,opt,name=shadow,proto3" json:"shadow,omitempty"`
+	// pass, fail, error or shadow-fail
+	Outcome   string               `protobuf:"bytes,5,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Duration  *durationpb.Duration `protobuf:"bytes,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	ErrorCode int32                `protobuf:"varint,7,opt,name=errorCode,proto3" json:"errorCode,omitempty"`
+	ErrorName string               `protobuf:"bytes,8,opt,name=errorName,proto3" json:"errorName,omitempty"`
+	Message   string               `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"`
+	// error returned by the rule, and the error policy applied to it
+	Error         string `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorPolicy   string `protobuf:"bytes,11,opt,name=errorPolicy,proto3" json:"errorPolicy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleEvaluation) Reset() {
+	*x = RuleEvaluation{}
+	mi := &file_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleEvaluation) ProtoMessage() {}
+
+func (x *RuleEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleEvaluation.ProtoReflect.Descriptor instead.
+func (*RuleEvaluation) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RuleEvaluation) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *RuleEvaluation) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RuleEvaluation) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *RuleEvaluation) GetShadow() bool {
+	if x != nil {
+		return x.Shadow
+	}
+	return false
+}
+
+func (x *RuleEvaluation) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *RuleEvaluation) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *RuleEvaluation) GetErrorCode() int32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+func (x *RuleEvaluation) GetErrorName() string {
+	if x != nil {
+		return x.ErrorName
+	}
+	return ""
+}
+
+func (x *RuleEvaluation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RuleEvaluation) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RuleEvaluation) GetErrorPolicy() string {
+	if x != nil {
+		return x.ErrorPolicy
+	}
+	return ""
+}
+
+type RecordEvaluation struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Key          string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	UserId       string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	IsSuccess    bool                   `protobuf:"varint,3,opt,name=isSuccess,proto3" json:"isSuccess,omitempty"`
+	ErrorCode    int32                  `protobuf:"varint,4,opt,name=errorCode,proto3" json:"errorCode,omitempty"`
+	ErrorMessage string                 `protobuf:"bytes,5,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
+	// rules matching the hook and the key, in evaluation order
+	Rules         []*RuleEvaluation `protobuf:"bytes,6,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordEvaluation) Reset() {
+	*x = RecordEvaluation{}
+	mi := &file_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordEvaluation) ProtoMessage() {}
+
+func (x *RecordEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordEvaluation.ProtoReflect.Descriptor instead.
+func (*RecordEvaluation) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RecordEvaluation) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *RecordEvaluation) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RecordEvaluation) GetIsSuccess() bool {
+	if x != nil {
+		return x.IsSuccess
+	}
+	return false
+}
+
+func (x *RecordEvaluation) GetErrorCode() int32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+func (x *RecordEvaluation) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *RecordEvaluation) GetRules() []*RuleEvaluation {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type EvaluateResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Records []*RecordEvaluation    `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	// gRPC status of the call, when it failed
+	StatusCode    int32  `protobuf:"varint,2,opt,name=statusCode,proto3" json:"statusCode,omitempty"`
+	StatusMessage string `protobuf:"bytes,3,opt,name=statusMessage,proto3" json:"statusMessage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateResponse) Reset() {
+	*x = EvaluateResponse{}
+	mi := &file_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateResponse) ProtoMessage() {}
+
+func (x *EvaluateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EvaluateResponse) GetRecords() []*RecordEvaluation {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *EvaluateResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *EvaluateResponse) GetStatusMessage() string {
+	if x != nil {
+		return x.StatusMessage
+	}
+	return ""
+}
+
+type SetRuleModeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Rule  string                 `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	// enforce, shadow or disabled
+	Mode          string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRuleModeRequest) Reset() {
+	*x = SetRuleModeRequest{}
+	mi := &file_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRuleModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRuleModeRequest) ProtoMessage() {}
+
+func (x *SetRuleModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRuleModeRequest.ProtoReflect.Descriptor instead.
+func (*SetRuleModeRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SetRuleModeRequest) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *SetRuleModeRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+type SetRuleModeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *Rule                  `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	ConfigHash    string                 `protobuf:"bytes,2,opt,name=configHash,proto3" json:"configHash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRuleModeResponse) Reset() {
+	*x = SetRuleModeResponse{}
+	mi := &file_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRuleModeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRuleModeResponse) ProtoMessage() {}
+
+func (x *SetRuleModeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRuleModeResponse.ProtoReflect.Descriptor instead.
+func (*SetRuleModeResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetRuleModeResponse) GetRule() *Rule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *SetRuleModeResponse) GetConfigHash() string {
+	if x != nil {
+		return x.ConfigHash
+	}
+	return ""
+}
+
+type SetShadowModeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetShadowModeRequest) Reset() {
+	*x = SetShadowModeRequest{}
+	mi := &file_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetShadowModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetShadowModeRequest) ProtoMessage() {}
+
+func (x *SetShadowModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetShadowModeRequest.ProtoReflect.Descriptor instead.
+func (*SetShadowModeRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetShadowModeRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SetShadowModeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetShadowModeResponse) Reset() {
+	*x = SetShadowModeResponse{}
+	mi := &file_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetShadowModeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetShadowModeResponse) ProtoMessage() {}
+
+func (x *SetShadowModeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetShadowModeResponse.ProtoReflect.Descriptor instead.
+func (*SetShadowModeResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SetShadowModeResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type ReloadRuleSetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadRuleSetRequest) Reset() {
+	*x = ReloadRuleSetRequest{}
+	mi := &file_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadRuleSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadRuleSetRequest) ProtoMessage() {}
+
+func (x *ReloadRuleSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadRuleSetRequest.ProtoReflect.Descriptor instead.
+func (*ReloadRuleSetRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{13}
+}
+
+type ReloadRuleSetResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RuleSetVersion string                 `protobuf:"bytes,1,opt,name=ruleSetVersion,proto3" json:"ruleSetVersion,omitempty"`
+	ConfigHash     string                 `protobuf:"bytes,2,opt,name=configHash,proto3" json:"configHash,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReloadRuleSetResponse) Reset() {
+	*x = ReloadRuleSetResponse{}
+	mi := &file_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadRuleSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadRuleSetResponse) ProtoMessage() {}
+
+func (x *ReloadRuleSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadRuleSetResponse.ProtoReflect.Descriptor instead.
+func (*ReloadRuleSetResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReloadRuleSetResponse) GetRuleSetVersion() string {
+	if x != nil {
+		return x.RuleSetVersion
+	}
+	return ""
+}
+
+func (x *ReloadRuleSetResponse) GetConfigHash() string {
+	if x != nil {
+		return x.ConfigHash
+	}
+	return ""
+}
+
+var File_admin_proto protoreflect.FileDescriptor
+
+const file_admin_proto_rawDesc = "" +
+	"\n" +
+	"\vadmin.proto\x12#accelbyte.cloudsave.validator.admin\x1a\x1egoogle/protobuf/duration.proto\"~\n" +
+	"\x04Rule\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1e\n" +
+	"\n" +
+	"keyPattern\x18\x03 \x01(\tR\n" +
+	"keyPattern\x12\x14\n" +
+	"\x05hooks\x18\x04 \x03(\tR\x05hooks\x12\x12\n" +
+	"\x04mode\x18\x05 \x01(\tR\x04mode\"\x12\n" +
+	"\x10ListRulesRequest\"|\n" +
+	"\x11ListRulesResponse\x12&\n" +
+	"\x0eruleSetVersion\x18\x01 \x01(\tR\x0eruleSetVersion\x12?\n" +
+	"\x05rules\x18\x02 \x03(\v2).accelbyte.cloudsave.validator.admin.RuleR\x05rules\"\x12\n" +
+	"\x10GetConfigRequest\"\xc3\x01\n" +
+	"\x11GetConfigResponse\x12&\n" +
+	"\x0eruleSetVersion\x18\x01 \x01(\tR\x0eruleSetVersion\x12\x1e\n" +
+	"\n" +
+	"configHash\x18\x02 \x01(\tR\n" +
+	"configHash\x12$\n" +
+	"\rruleSetConfig\x18\x03 \x01(\tR\rruleSetConfig\x12 \n" +
+	"\vruleSetFile\x18\x04 \x01(\tR\vruleSetFile\x12\x1e\n" +
+	"\n" +
+	"shadowMode\x18\x05 \x01(\bR\n" +
+	"shadowMode\"]\n" +
+	"\x0fEvaluateRequest\x12\x12\n" +
+	"\x04hook\x18\x01 \x01(\tR\x04hook\x12\x1e\n" +
+	"\n" +
+	"recordJson\x18\x02 \x01(\tR\n" +
+	"recordJson\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\"\xc9\x02\n" +
+	"\x0eRuleEvaluation\x12\x12\n" +
+	"\x04rule\x18\x01 \x01(\tR\x04rule\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\tR\x04mode\x12\x16\n" +
+	"\x06shadow\x18\x04 \x01(\bR\x06shadow\x12\x18\n" +
+	"\aoutcome\x18\x05 \x01(\tR\aoutcome\x125\n" +
+	"\bduration\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x1c\n" +
+	"\terrorCode\x18\a \x01(\x05R\terrorCode\x12\x1c\n" +
+	"\terrorName\x18\b \x01(\tR\terrorName\x12\x18\n" +
+	"\amessage\x18\t \x01(\tR\amessage\x12\x14\n" +
+	"\x05error\x18\n" +
+	" \x01(\tR\x05error\x12 \n" +
+	"\verrorPolicy\x18\v \x01(\tR\verrorPolicy\"\xe7\x01\n" +
+	"\x10RecordEvaluation\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\x12\x1c\n" +
+	"\tisSuccess\x18\x03 \x01(\bR\tisSuccess\x12\x1c\n" +
+	"\terrorCode\x18\x04 \x01(\x05R\terrorCode\x12\"\n" +
+	"\ferrorMessage\x18\x05 \x01(\tR\ferrorMessage\x12I\n" +
+	"\x05rules\x18\x06 \x03(\v23.accelbyte.cloudsave.validator.admin.RuleEvaluationR\x05rules\"\xa9\x01\n" +
+	"\x10EvaluateResponse\x12O\n" +
+	"\arecords\x18\x01 \x03(\v25.accelbyte.cloudsave.validator.admin.RecordEvaluationR\arecords\x12\x1e\n" +
+	"\n" +
+	"statusCode\x18\x02 \x01(\x05R\n" +
+	"statusCode\x12$\n" +
+	"\rstatusMessage\x18\x03 \x01(\tR\rstatusMessage\"<\n" +
+	"\x12SetRuleModeRequest\x12\x12\n" +
+	"\x04rule\x18\x01 \x01(\tR\x04rule\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\"t\n" +
+	"\x13SetRuleModeResponse\x12=\n" +
+	"\x04rule\x18\x01 \x01(\v2).accelbyte.cloudsave.validator.admin.RuleR\x04rule\x12\x1e\n" +
+	"\n" +
+	"configHash\x18\x02 \x01(\tR\n" +
+	"configHash\"0\n" +
+	"\x14SetShadowModeRequest\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\"1\n" +
+	"\x15SetShadowModeResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\"\x16\n" +
+	"\x14ReloadRuleSetRequest\"_\n" +
+	"\x15ReloadRuleSetResponse\x12&\n" +
+	"\x0eruleSetVersion\x18\x01 \x01(\tR\x0eruleSetVersion\x12\x1e\n" +
+	"\n" +
+	"configHash\x18\x02 \x01(\tR\n" +
+	"configHash2\xa6\x06\n" +
+	"\x1eCloudsaveValidatorAdminService\x12z\n" +
+	"\tListRules\x125.accelbyte.cloudsave.validator.admin.ListRulesRequest\x1a6.accelbyte.cloudsave.validator.admin.ListRulesResponse\x12z\n" +
+	"\tGetConfig\x125.accelbyte.cloudsave.validator.admin.GetConfigRequest\x1a6.accelbyte.cloudsave.validator.admin.GetConfigResponse\x12w\n" +
+	"\bEvaluate\x124.accelbyte.cloudsave.validator.admin.EvaluateRequest\x1a5.accelbyte.cloudsave.validator.admin.EvaluateResponse\x12\x80\x01\n" +
+	"\vSetRuleMode\x127.accelbyte.cloudsave.validator.admin.SetRuleModeRequest\x1a8.accelbyte.cloudsave.validator.admin.SetRuleModeResponse\x12\x86\x01\n" +
+	"\rSetShadowMode\x129.accelbyte.cloudsave.validator.admin.SetShadowModeRequest\x1a:.accelbyte.cloudsave.validator.admin.SetShadowModeResponse\x12\x86\x01\n" +
+	"\rReloadRuleSet\x129.accelbyte.cloudsave.validator.admin.ReloadRuleSetRequest\x1a:.accelbyte.cloudsave.validator.admin.ReloadRuleSetResponseBt\n" +
+	"'net.accelbyte.cloudsave.validator.adminP\x01Z!accelbyte.net/cloudsave/validator\xaa\x02#AccelByte.Cloudsave.Validator.Adminb\x06proto3"
+
+var (
+	file_admin_proto_rawDescOnce sync.Once
+	file_admin_proto_rawDescData []byte
+)
+
+func file_admin_proto_rawDescGZIP() []byte {
+	file_admin_proto_rawDescOnce.Do(func() {
+		file_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_admin_proto_rawDesc), len(file_admin_proto_rawDesc)))
+	})
+	return file_admin_proto_rawDescData
+}
+
+var file_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_admin_proto_goTypes = []any{
+	(*Rule)(nil),                  // 0: accelbyte.cloudsave.validator.admin.Rule
+	(*ListRulesRequest)(nil),      // 1: accelbyte.cloudsave.validator.admin.ListRulesRequest
+	(*ListRulesResponse)(nil),     // 2: accelbyte.cloudsave.validator.admin.ListRulesResponse
+	(*GetConfigRequest)(nil),      // 3: accelbyte.cloudsave.validator.admin.GetConfigRequest
+	(*GetConfigResponse)(nil),     // 4: accelbyte.cloudsave.validator.admin.GetConfigResponse
+	(*EvaluateRequest)(nil),       // 5: accelbyte.cloudsave.validator.admin.EvaluateRequest
+	(*RuleEvaluation)(nil),        // 6: accelbyte.cloudsave.validator.admin.RuleEvaluation
+	(*RecordEvaluation)(nil),      // 7: accelbyte.cloudsave.validator.admin.RecordEvaluation
+	(*EvaluateResponse)(nil),      // 8: accelbyte.cloudsave.validator.admin.EvaluateResponse
+	(*SetRuleModeRequest)(nil),    // 9: accelbyte.cloudsave.validator.admin.SetRuleModeRequest
+	(*SetRuleModeResponse)(nil),   // 10: accelbyte.cloudsave.validator.admin.SetRuleModeResponse
+	(*SetShadowModeRequest)(nil),  // 11: accelbyte.cloudsave.validator.admin.SetShadowModeRequest
+	(*SetShadowModeResponse)(nil), // 12: accelbyte.cloudsave.validator.admin.SetShadowModeResponse
+	(*ReloadRuleSetRequest)(nil),  // 13: accelbyte.cloudsave.validator.admin.ReloadRuleSetRequest
+	(*ReloadRuleSetResponse)(nil), // 14: accelbyte.cloudsave.validator.admin.ReloadRuleSetResponse
+	(*durationpb.Duration)(nil),   // 15: google.protobuf.Duration
+}
+var file_admin_proto_depIdxs = []int32{
+	0,  // 0: accelbyte.cloudsave.validator.admin.ListRulesResponse.rules:type_name -> accelbyte.cloudsave.validator.admin.Rule
+	15, // 1: accelbyte.cloudsave.validator.admin.RuleEvaluation.duration:type_name -> google.protobuf.Duration
+	6,  // 2: accelbyte.cloudsave.validator.admin.RecordEvaluation.rules:type_name -> accelbyte.cloudsave.validator.admin.RuleEvaluation
+	7,  // 3: accelbyte.cloudsave.validator.admin.EvaluateResponse.records:type_name -> accelbyte.cloudsave.validator.admin.RecordEvaluation
+	0,  // 4: accelbyte.cloudsave.validator.admin.SetRuleModeResponse.rule:type_name -> accelbyte.cloudsave.validator.admin.Rule
+	1,  // 5: accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService.ListRules:input_type -> accelbyte.cloudsave.validator.admin.ListRulesRequest
+	3,  // 6: accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService.GetConfig:input_type -> accelbyte.cloudsave.validator.admin.GetConfigRequest
+	5,  // 7: accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService.Evaluate:input_type -> accelbyte.cloudsave.validator.admin.EvaluateRequest
+	9,  // 8: accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService.SetRuleMode:input_type -> accelbyte.cloudsave.validator.admin.SetRuleModeRequest
+	11, // 9: accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService.SetShadowMode:input_type -> accelbyte.cloudsave.validator.admin.SetShadowModeRequest
+	13, // 10: accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService.ReloadRuleSet:input_type -> accelbyte.cloudsave.validator.admin.ReloadRuleSetRequest
+	2,  // 11: accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService.ListRules:output_type -> accelbyte.cloudsave.validator.admin.ListRulesResponse
+	4,  // 12: accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService.GetConfig:output_type -> accelbyte.cloudsave.validator.admin.GetConfigResponse
+	8,  // 13: accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService.Evaluate:output_type -> accelbyte.cloudsave.validator.admin.EvaluateResponse
+	10, // 14: accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService.SetRuleMode:output_type -> accelbyte.cloudsave.validator.admin.SetRuleModeResponse
+	12, // 15: accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService.SetShadowMode:output_type -> accelbyte.cloudsave.validator.admin.SetShadowModeResponse
+	14, // 16: accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService.ReloadRuleSet:output_type -> accelbyte.cloudsave.validator.admin.ReloadRuleSetResponse
+	11, // [11:17] is the sub-list for method output_type
+	5,  // [5:11] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_admin_proto_init() }
+func file_admin_proto_init() {
+	if File_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_admin_proto_rawDesc), len(file_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_admin_proto_goTypes,
+		DependencyIndexes: file_admin_proto_depIdxs,
+		MessageInfos:      file_admin_proto_msgTypes,
+	}.Build()
+	File_admin_proto = out.File
+	file_admin_proto_goTypes = nil
+	file_admin_proto_depIdxs = nil
+}
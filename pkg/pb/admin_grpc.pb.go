@@ -0,0 +1,325 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v3.21.9
+// source: admin.proto
+
+package validator
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CloudsaveValidatorAdminService_ListRules_FullMethodName     = "/accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService/ListRules"
+	CloudsaveValidatorAdminService_GetConfig_FullMethodName     = "/accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService/GetConfig"
+	CloudsaveValidatorAdminService_Evaluate_FullMethodName      = "/accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService/Evaluate"
+	CloudsaveValidatorAdminService_SetRuleMode_FullMethodName   = "/accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService/SetRuleMode"
+	CloudsaveValidatorAdminService_SetShadowMode_FullMethodName = "/accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService/SetShadowMode"
+	CloudsaveValidatorAdminService_ReloadRuleSet_FullMethodName = "/accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService/ReloadRuleSet"
+)
+
+// CloudsaveValidatorAdminServiceClient is the client API for CloudsaveValidatorAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CloudsaveValidatorAdminService manages a running validator. It is served on
+// its own port.
+type CloudsaveValidatorAdminServiceClient interface {
+	ListRules(ctx context.Context, in *ListRulesRequest, opts ...grpc.CallOption) (*ListRulesResponse, error)
+	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error)
+	// evaluate a record without CloudSave, explaining the result of every rule
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+	// change the mode of a rule until the next reload
+	SetRuleMode(ctx context.Context, in *SetRuleModeRequest, opts ...grpc.CallOption) (*SetRuleModeResponse, error)
+	SetShadowMode(ctx context.Context, in *SetShadowModeRequest, opts ...grpc.CallOption) (*SetShadowModeResponse, error)
+	// reload the rule set file
+	ReloadRuleSet(ctx context.Context, in *ReloadRuleSetRequest, opts ...grpc.CallOption) (*ReloadRuleSetResponse, error)
+}
+
+type cloudsaveValidatorAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCloudsaveValidatorAdminServiceClient(cc grpc.ClientConnInterface) CloudsaveValidatorAdminServiceClient {
+	return &cloudsaveValidatorAdminServiceClient{cc}
+}
+
+func (c *cloudsaveValidatorAdminServiceClient) ListRules(ctx context.Context, in *ListRulesRequest, opts ...grpc.CallOption) (*ListRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRulesResponse)
+	err := c.cc.Invoke(ctx, CloudsaveValidatorAdminService_ListRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudsaveValidatorAdminServiceClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConfigResponse)
+	err := c.cc.Invoke(ctx, CloudsaveValidatorAdminService_GetConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudsaveValidatorAdminServiceClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvaluateResponse)
+	err := c.cc.Invoke(ctx, CloudsaveValidatorAdminService_Evaluate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudsaveValidatorAdminServiceClient) SetRuleMode(ctx context.Context, in *SetRuleModeRequest, opts ...grpc.CallOption) (*SetRuleModeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetRuleModeResponse)
+	err := c.cc.Invoke(ctx, CloudsaveValidatorAdminService_SetRuleMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudsaveValidatorAdminServiceClient) SetShadowMode(ctx context.Context, in *SetShadowModeRequest, opts ...grpc.CallOption) (*SetShadowModeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetShadowModeResponse)
+	err := c.cc.Invoke(ctx, CloudsaveValidatorAdminService_SetShadowMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudsaveValidatorAdminServiceClient) ReloadRuleSet(ctx context.Context, in *ReloadRuleSetRequest, opts ...grpc.CallOption) (*ReloadRuleSetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReloadRuleSetResponse)
+	err := c.cc.Invoke(ctx, CloudsaveValidatorAdminService_ReloadRuleSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CloudsaveValidatorAdminServiceServer is the server API for CloudsaveValidatorAdminService service.
+// All implementations should embed UnimplementedCloudsaveValidatorAdminServiceServer
+// for forward compatibility.
+//
+// CloudsaveValidatorAdminService manages a running validator. It is served on
+// its own port.
+type CloudsaveValidatorAdminServiceServer interface {
+	ListRules(context.Context, *ListRulesRequest) (*ListRulesResponse, error)
+	GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error)
+	// evaluate a record without CloudSave, explaining the result of every rule
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+	// change the mode of a rule until the next reload
+	SetRuleMode(context.Context, *SetRuleModeRequest) (*SetRuleModeResponse, error)
+	SetShadowMode(context.Context, *SetShadowModeRequest) (*SetShadowModeResponse, error)
+	// reload the rule set file
+	ReloadRuleSet(context.Context, *ReloadRuleSetRequest) (*ReloadRuleSetResponse, error)
+}
+
+// UnimplementedCloudsaveValidatorAdminServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCloudsaveValidatorAdminServiceServer struct{}
+
+func (UnimplementedCloudsaveValidatorAdminServiceServer) ListRules(context.Context, *ListRulesRequest) (*ListRulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRules not implemented")
+}
+func (UnimplementedCloudsaveValidatorAdminServiceServer) GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (UnimplementedCloudsaveValidatorAdminServiceServer) Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Evaluate not implemented")
+}
+func (UnimplementedCloudsaveValidatorAdminServiceServer) SetRuleMode(context.Context, *SetRuleModeRequest) (*SetRuleModeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetRuleMode not implemented")
+}
+func (UnimplementedCloudsaveValidatorAdminServiceServer) SetShadowMode(context.Context, *SetShadowModeRequest) (*SetShadowModeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetShadowMode not implemented")
+}
+func (UnimplementedCloudsaveValidatorAdminServiceServer) ReloadRuleSet(context.Context, *ReloadRuleSetRequest) (*ReloadRuleSetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReloadRuleSet not implemented")
+}
+func (UnimplementedCloudsaveValidatorAdminServiceServer) testEmbeddedByValue() {}
+
+// UnsafeCloudsaveValidatorAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CloudsaveValidatorAdminServiceServer will
+// result in compilation errors.
+type UnsafeCloudsaveValidatorAdminServiceServer interface {
+	mustEmbedUnimplementedCloudsaveValidatorAdminServiceServer()
+}
+
+func RegisterCloudsaveValidatorAdminServiceServer(s grpc.ServiceRegistrar, srv CloudsaveValidatorAdminServiceServer) {
+	// If the following call panics, it indicates UnimplementedCloudsaveValidatorAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CloudsaveValidatorAdminService_ServiceDesc, srv)
+}
+
+func _CloudsaveValidatorAdminService_ListRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudsaveValidatorAdminServiceServer).ListRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudsaveValidatorAdminService_ListRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudsaveValidatorAdminServiceServer).ListRules(ctx, req.(*ListRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudsaveValidatorAdminService_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudsaveValidatorAdminServiceServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudsaveValidatorAdminService_GetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudsaveValidatorAdminServiceServer).GetConfig(ctx, req.(*GetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudsaveValidatorAdminService_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudsaveValidatorAdminServiceServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudsaveValidatorAdminService_Evaluate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudsaveValidatorAdminServiceServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudsaveValidatorAdminService_SetRuleMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRuleModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudsaveValidatorAdminServiceServer).SetRuleMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudsaveValidatorAdminService_SetRuleMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudsaveValidatorAdminServiceServer).SetRuleMode(ctx, req.(*SetRuleModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudsaveValidatorAdminService_SetShadowMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetShadowModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudsaveValidatorAdminServiceServer).SetShadowMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudsaveValidatorAdminService_SetShadowMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudsaveValidatorAdminServiceServer).SetShadowMode(ctx, req.(*SetShadowModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudsaveValidatorAdminService_ReloadRuleSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadRuleSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudsaveValidatorAdminServiceServer).ReloadRuleSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CloudsaveValidatorAdminService_ReloadRuleSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudsaveValidatorAdminServiceServer).ReloadRuleSet(ctx, req.(*ReloadRuleSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CloudsaveValidatorAdminService_ServiceDesc is the grpc.ServiceDesc for CloudsaveValidatorAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CloudsaveValidatorAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "accelbyte.cloudsave.validator.admin.CloudsaveValidatorAdminService",
+	HandlerType: (*CloudsaveValidatorAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRules",
+			Handler:    _CloudsaveValidatorAdminService_ListRules_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _CloudsaveValidatorAdminService_GetConfig_Handler,
+		},
+		{
+			MethodName: "Evaluate",
+			Handler:    _CloudsaveValidatorAdminService_Evaluate_Handler,
+		},
+		{
+			MethodName: "SetRuleMode",
+			Handler:    _CloudsaveValidatorAdminService_SetRuleMode_Handler,
+		},
+		{
+			MethodName: "SetShadowMode",
+			Handler:    _CloudsaveValidatorAdminService_SetShadowMode_Handler,
+		},
+		{
+			MethodName: "ReloadRuleSet",
+			Handler:    _CloudsaveValidatorAdminService_ReloadRuleSet_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin.proto",
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v3.21.9
+// source: capture.proto
+
+package validator
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CapturedCall is a call to CloudsaveValidatorService recorded in capture mode.
+// Capture files are sequences of size-delimited CapturedCall messages, or of
+// CapturedCall JSON objects, one per line.
+type CapturedCall struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Time  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// full gRPC method name, e.g. /accelbyte.cloudsave.validator.CloudsaveValidatorService/BeforeWriteGameRecord
+	Method  string     `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Request *anypb.Any `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	// response is not set when the call failed
+	Response *anypb.Any `protobuf:"bytes,4,opt,name=response,proto3" json:"response,omitempty"`
+	// gRPC status of the call
+	StatusCode    int32  `protobuf:"varint,5,opt,name=statusCode,proto3" json:"statusCode,omitempty"`
+	StatusMessage string `protobuf:"bytes,6,opt,name=statusMessage,proto3" json:"statusMessage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapturedCall) Reset() {
+	*x = CapturedCall{}
+	mi := &file_capture_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapturedCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapturedCall) ProtoMessage() {}
+
+func (x *CapturedCall) ProtoReflect() protoreflect.Message {
+	mi := &file_capture_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapturedCall.ProtoReflect.Descriptor instead.
+func (*CapturedCall) Descriptor() ([]byte, []int) {
+	return file_capture_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CapturedCall) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *CapturedCall) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *CapturedCall) GetRequest() *anypb.Any {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *CapturedCall) GetResponse() *anypb.Any {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *CapturedCall) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *CapturedCall) GetStatusMessage() string {
+	if x != nil {
+		return x.StatusMessage
+	}
+	return ""
+}
+
+var File_capture_proto protoreflect.FileDescriptor
+
+const file_capture_proto_rawDesc = "" +
+	"\n" +
+	"\rcapture.proto\x12%accelbyte.cloudsave.validator.capture\x1a\x19google/protobuf/any.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfe\x01\n" +
+	"\fCapturedCall\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12.\n" +
+	"\arequest\x18\x03 \x01(\v2\x14.google.protobuf.AnyR\arequest\x120\n" +
+	"\bresponse\x18\x04 \x01(\v2\x14.google.protobuf.AnyR\bresponse\x12\x1e\n" +
+	"\n" +
+	"statusCode\x18\x05 \x01(\x05R\n" +
+	"statusCode\x12$\n" +
+	"\rstatusMessage\x18\x06 \x01(\tR\rstatusMessageBx\n" +
+	")net.accelbyte.cloudsave.validator.captureP\x01Z!accelbyte.net/cloudsave/validator\xaa\x02%AccelByte.Cloudsave.Validator.Captureb\x06proto3"
+
+var (
+	file_capture_proto_rawDescOnce sync.Once
+	file_capture_proto_rawDescData []byte
+)
+
+func file_capture_proto_rawDescGZIP() []byte {
+	file_capture_proto_rawDescOnce.Do(func() {
+		file_capture_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_capture_proto_rawDesc), len(file_capture_proto_rawDesc)))
+	})
+	return file_capture_proto_rawDescData
+}
+
+var file_capture_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_capture_proto_goTypes = []any{
+	(*CapturedCall)(nil),          // 0: accelbyte.cloudsave.validator.capture.CapturedCall
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+	(*anypb.Any)(nil),             // 2: google.protobuf.Any
+}
+var file_capture_proto_depIdxs = []int32{
+	1, // 0: accelbyte.cloudsave.validator.capture.CapturedCall.time:type_name -> google.protobuf.Timestamp
+	2, // 1: accelbyte.cloudsave.validator.capture.CapturedCall.request:type_name -> google.protobuf.Any
+	2, // 2: accelbyte.cloudsave.validator.capture.CapturedCall.response:type_name -> google.protobuf.Any
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_capture_proto_init() }
+func file_capture_proto_init() {
+	if File_capture_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_capture_proto_rawDesc), len(file_capture_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_capture_proto_goTypes,
+		DependencyIndexes: file_capture_proto_depIdxs,
+		MessageInfos:      file_capture_proto_msgTypes,
+	}.Build()
+	File_capture_proto = out.File
+	file_capture_proto_goTypes = nil
+	file_capture_proto_depIdxs = nil
+}
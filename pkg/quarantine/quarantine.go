@@ -0,0 +1,296 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+// Package quarantine keeps the payloads of rejected records, so the exact
+// content sent by a client can be inspected when debugging a rejection.
+package quarantine
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is a quarantined record.
+type Entry struct {
+	ID        string    `json:"id"`
+	Time      time.Time `json:"time"`
+	Namespace string    `json:"namespace"`
+	UserID    string    `json:"userId,omitempty"`
+	Key       string    `json:"key"`
+	Hook      string    `json:"hook"`
+	Rules     []string  `json:"rules"`
+	ErrorCode int32     `json:"errorCode"`
+	// Payload is the JSON payload of the record, truncated to the maximum
+	// payload size of the quarantine.
+	Payload     []byte `json:"payload,omitempty"`
+	PayloadSize int    `json:"payloadSize"`
+	Truncated   bool   `json:"truncated,omitempty"`
+	// BinaryURL is the URL of the content of a binary record.
+	BinaryURL string `json:"binaryUrl,omitempty"`
+}
+
+// Config are the limits of a Quarantine.
+type Config struct {
+	// Retention is how long entries are kept, 0 keeps them forever.
+	Retention time.Duration
+	// MaxPayloadBytes truncates larger payloads, 0 means no limit.
+	MaxPayloadBytes int
+	// MaxTotalBytes deletes the oldest entries once the stored entries exceed
+	// it, 0 means no limit.
+	MaxTotalBytes int64
+	// EncryptionKey encrypts the entries with AES-GCM when set. It must be 16,
+	// 24 or 32 bytes long.
+	EncryptionKey []byte
+	// BufferSize is the maximum number of entries waiting to be stored.
+	BufferSize int
+	// PruneInterval is how often the retention limits are enforced.
+	PruneInterval time.Duration
+}
+
+// Quarantine stores entries in the background, so quarantining a record never
+// blocks the caller, and deletes the entries exceeding its limits.
+type Quarantine struct {
+	store  Store
+	config Config
+	aead   cipher.AEAD
+
+	mu      sync.RWMutex
+	closed  bool
+	entries chan Entry
+	done    chan struct{}
+}
+
+// New returns a Quarantine storing entries in store. Call Start to store the
+// added entries.
+func New(store Store, config Config) (*Quarantine, error) {
+	q := &Quarantine{store: store, config: config}
+	if len(config.EncryptionKey) > 0 {
+		block, err := aes.NewCipher(config.EncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key: %w", err)
+		}
+		if q.aead, err = cipher.NewGCM(block); err != nil {
+			return nil, err
+		}
+	}
+
+	return q, nil
+}
+
+// Start stores the added entries and prunes the store in the background,
+// until Close.
+func (q *Quarantine) Start() {
+	q.entries = make(chan Entry, q.config.BufferSize)
+	q.done = make(chan struct{})
+	go q.run()
+}
+
+// Add queues entry, setting its ID and truncating its payload, and returns
+// false when the buffer is full, or the quarantine closed, and the entry was
+// dropped.
+func (q *Quarantine) Add(entry Entry) bool {
+	entry.ID = newID(entry.Namespace, entry.Time)
+	entry.PayloadSize = len(entry.Payload)
+	if q.config.MaxPayloadBytes > 0 && len(entry.Payload) > q.config.MaxPayloadBytes {
+		entry.Payload = entry.Payload[:q.config.MaxPayloadBytes]
+		entry.Truncated = true
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return false
+	}
+	select {
+	case q.entries <- entry:
+		return true
+	default:
+		return false
+	}
+}
+
+// Buffered returns the number of entries waiting to be stored and the size
+// of the buffer.
+func (q *Quarantine) Buffered() (int, int) {
+	return len(q.entries), cap(q.entries)
+}
+
+// Close stores the buffered entries. Entries added after Close are dropped.
+func (q *Quarantine) Close(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.entries)
+	}
+	q.mu.Unlock()
+
+	select {
+	case <-q.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *Quarantine) run() {
+	defer close(q.done)
+
+	interval := q.config.PruneInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case entry, ok := <-q.entries:
+			if !ok {
+				return
+			}
+			if err := q.put(context.Background(), entry); err != nil {
+				slog.Error("failed to quarantine record", "key", entry.Key, "error", err)
+			}
+		case <-ticker.C:
+			if err := q.Prune(context.Background()); err != nil {
+				slog.Error("failed to prune quarantine", "error", err)
+			}
+		}
+	}
+}
+
+func (q *Quarantine) put(ctx context.Context, entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if q.aead != nil {
+		nonce := make([]byte, q.aead.NonceSize())
+		if _, err = rand.Read(nonce); err != nil {
+			return err
+		}
+		data = q.aead.Seal(nonce, nonce, data, []byte(entry.ID))
+	}
+
+	return q.store.Put(ctx, q.objectName(entry.ID), data)
+}
+
+// Get returns the entry with id.
+func (q *Quarantine) Get(ctx context.Context, id string) (*Entry, error) {
+	data, err := q.store.Get(ctx, q.objectName(id))
+	if err != nil {
+		return nil, err
+	}
+	if q.aead != nil {
+		if len(data) < q.aead.NonceSize() {
+			return nil, errors.New("invalid encrypted entry")
+		}
+		nonce, ciphertext := data[:q.aead.NonceSize()], data[q.aead.NonceSize():]
+		if data, err = q.aead.Open(nil, nonce, ciphertext, []byte(id)); err != nil {
+			return nil, fmt.Errorf("decrypt entry: %w", err)
+		}
+	}
+
+	var entry Entry
+	if err = json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// Info describes a stored entry without reading it.
+type Info struct {
+	ID      string
+	Size    int64
+	ModTime time.Time
+}
+
+// List returns the stored entries, oldest first.
+func (q *Quarantine) List(ctx context.Context) ([]Info, error) {
+	objects, err := q.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	extension := q.extension()
+	infos := make([]Info, 0, len(objects))
+	for _, object := range objects {
+		id, found := strings.CutSuffix(object.Name, extension)
+		if !found {
+			continue
+		}
+		infos = append(infos, Info{ID: id, Size: object.Size, ModTime: object.ModTime})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ModTime.Before(infos[j].ModTime)
+	})
+
+	return infos, nil
+}
+
+// Prune deletes the entries older than the retention, then the oldest
+// entries until the stored entries fit in the maximum total size.
+func (q *Quarantine) Prune(ctx context.Context) error {
+	infos, err := q.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, info := range infos {
+		total += info.Size
+	}
+
+	now := time.Now()
+	for _, info := range infos {
+		expired := q.config.Retention > 0 && now.Sub(info.ModTime) > q.config.Retention
+		oversized := q.config.MaxTotalBytes > 0 && total > q.config.MaxTotalBytes
+		if !expired && !oversized {
+			break
+		}
+		if err = q.store.Delete(ctx, q.objectName(info.ID)); err != nil {
+			return err
+		}
+		total -= info.Size
+	}
+
+	return nil
+}
+
+func (q *Quarantine) objectName(id string) string {
+	return id + q.extension()
+}
+
+func (q *Quarantine) extension() string {
+	if q.aead != nil {
+		return ".json.enc"
+	}
+
+	return ".json"
+}
+
+// newID returns a unique ID grouping the entries by namespace and day.
+func newID(namespace string, t time.Time) string {
+	random := make([]byte, 4)
+	_, _ = rand.Read(random)
+	if namespace == "" {
+		namespace = "_"
+	}
+	namespace = strings.NewReplacer("/", "_", "\\", "_", "..", "_").Replace(namespace)
+	t = t.UTC()
+
+	return fmt.Sprintf("%s/%s/%s-%s", namespace, t.Format("2006-01-02"), t.Format("150405.000000000"), hex.EncodeToString(random))
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package quarantine
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memStore is a Store whose objects have the modification times set by the
+// test.
+type memStore struct {
+	objects map[string]Object
+}
+
+func (s *memStore) Put(_ context.Context, name string, data []byte) error {
+	s.objects[name] = Object{Name: name, Size: int64(len(data)), ModTime: time.Now()}
+
+	return nil
+}
+
+func (s *memStore) Get(context.Context, string) ([]byte, error) {
+	return nil, ErrNotFound
+}
+
+func (s *memStore) List(_ context.Context) ([]Object, error) {
+	objects := make([]Object, 0, len(s.objects))
+	for _, object := range s.objects {
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+func (s *memStore) Delete(_ context.Context, name string) error {
+	delete(s.objects, name)
+
+	return nil
+}
+
+func newQuarantine(t *testing.T, store Store, config Config) *Quarantine {
+	t.Helper()
+
+	config.BufferSize = 10
+	q, err := New(store, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.Start()
+
+	return q
+}
+
+func quarantined(t *testing.T, q *Quarantine, entries ...Entry) []Info {
+	t.Helper()
+
+	for _, entry := range entries {
+		if !q.Add(entry) {
+			t.Fatalf("entry of %s dropped", entry.Key)
+		}
+	}
+	if err := q.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	infos, err := q.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return infos
+}
+
+func TestEncryptedEntries(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewDirStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := bytes.Repeat([]byte{1}, 32)
+	payload := []byte(`{"secret":"do not store in clear"}`)
+
+	q := newQuarantine(t, store, Config{EncryptionKey: key})
+	infos := quarantined(t, q, Entry{Time: time.Now(), Namespace: "mygame", Key: "town_map", Payload: payload})
+	if len(infos) != 1 {
+		t.Fatalf("expected 1 entry, got %+v", infos)
+	}
+	id := infos[0].ID
+	if !strings.HasPrefix(id, "mygame/") {
+		t.Errorf("entry %s is not grouped by namespace", id)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(id)+".json.enc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("secret")) {
+		t.Error("the entry is stored in clear")
+	}
+
+	entry, err := q.Get(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.ID != id || entry.Key != "town_map" || !bytes.Equal(entry.Payload, payload) {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+
+	other, err := New(store, Config{EncryptionKey: bytes.Repeat([]byte{2}, 32)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = other.Get(context.Background(), id); err == nil {
+		t.Error("decrypted an entry with another key")
+	}
+	plain, err := New(store, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if infos, err = plain.List(context.Background()); err != nil || len(infos) != 0 {
+		t.Errorf("a quarantine without key listed %+v, %v", infos, err)
+	}
+}
+
+func TestInvalidEncryptionKey(t *testing.T) {
+	if _, err := New(&memStore{}, Config{EncryptionKey: []byte("short")}); err == nil {
+		t.Error("accepted a 5 bytes key")
+	}
+}
+
+func TestTruncatedPayload(t *testing.T) {
+	store, err := NewDirStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := newQuarantine(t, store, Config{MaxPayloadBytes: 4})
+	infos := quarantined(t, q, Entry{Time: time.Now(), Key: "town_map", Payload: []byte(`{"name":"Town"}`)})
+	if len(infos) != 1 {
+		t.Fatalf("expected 1 entry, got %+v", infos)
+	}
+
+	entry, err := q.Get(context.Background(), infos[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(entry.Payload) != `{"na` || !entry.Truncated || entry.PayloadSize != 15 {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Now()
+	objects := func() map[string]Object {
+		return map[string]Object{
+			"a.json": {Name: "a.json", Size: 100, ModTime: now.Add(-72 * time.Hour)},
+			"b.json": {Name: "b.json", Size: 100, ModTime: now.Add(-2 * time.Hour)},
+			"c.json": {Name: "c.json", Size: 100, ModTime: now.Add(-time.Hour)},
+			"d.json": {Name: "d.json", Size: 100, ModTime: now},
+			// not an entry, it is never deleted
+			"other.x": {Name: "other.x", Size: 1000, ModTime: now.Add(-100 * time.Hour)},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		config Config
+		want   []string
+	}{
+		{"no limit", Config{}, []string{"a.json", "b.json", "c.json", "d.json", "other.x"}},
+		{"retention", Config{Retention: 24 * time.Hour}, []string{"b.json", "c.json", "d.json", "other.x"}},
+		{"total size", Config{MaxTotalBytes: 250}, []string{"c.json", "d.json", "other.x"}},
+		{"both", Config{Retention: 90 * time.Minute, MaxTotalBytes: 150}, []string{"d.json", "other.x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &memStore{objects: objects()}
+			q, err := New(store, tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = q.Prune(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			var names []string
+			for name := range store.objects {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("kept %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestDirStoreConfinesNames(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewDirStore(filepath.Join(dir, "store"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = store.Put(context.Background(), "../../escaped.json", []byte("{}")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = os.Stat(filepath.Join(dir, "escaped.json")); err == nil {
+		t.Error("the object was written outside the store")
+	}
+	if _, err = os.Stat(filepath.Join(dir, "store", "escaped.json")); err != nil {
+		t.Errorf("the object is not in the store: %v", err)
+	}
+}
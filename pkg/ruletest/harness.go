@@ -0,0 +1,206 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package ruletest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/AccelByte/accelbyte-go-sdk/services-api/pkg/service/iam"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/capture"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/common"
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/server"
+)
+
+// Token is the access token accepted by the harness.
+const Token = "ruletest-token"
+
+// Harness serves a CloudsaveValidatorServiceServer on an in-memory gRPC
+// connection, behind the same auth interceptors as the app.
+type Harness struct {
+	server *grpc.Server
+	conn   *grpc.ClientConn
+	target capture.Target
+}
+
+// NewHarness serves srv. It replaces common.Validator with a validator that
+// only accepts Token, so harnesses must not be used by parallel tests
+// alongside a real validator.
+func NewHarness(srv pb.CloudsaveValidatorServiceServer) (*Harness, error) {
+	common.Validator = tokenValidator{}
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(common.UnaryAuthServerIntercept, nowInterceptor),
+		grpc.ChainStreamInterceptor(common.StreamAuthServerIntercept),
+	)
+	pb.RegisterCloudsaveValidatorServiceServer(server, srv)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		server.Stop()
+
+		return nil, err
+	}
+
+	return &Harness{server: server, conn: conn, target: capture.NewClientTarget(conn)}, nil
+}
+
+// Close stops the server.
+func (h *Harness) Close() {
+	_ = h.conn.Close()
+	h.server.Stop()
+}
+
+// Result is the outcome of a case.
+type Result struct {
+	Case    Case
+	Outcome capture.Outcome
+	// Message is the error message, or the status message when the call
+	// failed.
+	Message string
+	// Failure describes why the case failed, it is empty when it passed.
+	Failure string
+}
+
+func (r *Result) Passed() bool {
+	return r.Failure == ""
+}
+
+// Run sends the record of c to its hook and compares the result with the
+// expected one. An invalid case, e.g. with an unknown record field, fails.
+func (h *Harness) Run(ctx context.Context, c Case) *Result {
+	result := &Result{Case: c}
+	data, err := c.recordJSON()
+	if err != nil {
+		result.Failure = "invalid case: " + err.Error()
+
+		return result
+	}
+	request, err := capture.NewRequest(c.Hook, data)
+	if err != nil {
+		result.Failure = "invalid case: " + err.Error()
+
+		return result
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+Token)
+	if c.Locale != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-locale", c.Locale)
+	}
+	if c.Now != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, server.NowMetadataKey, c.Now)
+	}
+
+	method := "/" + pb.CloudsaveValidatorService_ServiceDesc.ServiceName + "/" + c.Hook
+	response, err := h.target.Invoke(ctx, method, request)
+
+	outcomes := capture.Outcomes(request, response, err)
+	if len(outcomes) != 1 {
+		result.Failure = fmt.Sprintf("expected 1 result, got %d", len(outcomes))
+
+		return result
+	}
+	result.Outcome = outcomes[0]
+	if err != nil {
+		result.Message = status.Convert(err).Message()
+	} else {
+		result.Message = capture.ErrorMessage(response)
+	}
+	result.Failure = check(c.Expect, result)
+
+	return result
+}
+
+func check(expect Expect, result *Result) string {
+	outcome := result.Outcome
+	if expect.Status != "" {
+		code, _ := parseStatus(expect.Status)
+		if outcome.Status != code.String() {
+			return fmt.Sprintf("expected status %s, got %s", code, describe(outcome))
+		}
+	} else {
+		switch {
+		case outcome.Status != "":
+			return fmt.Sprintf("expected %s, got status %s: %s", describeExpect(expect), outcome.Status, result.Message)
+		case outcome.IsSuccess != expect.Success:
+			return fmt.Sprintf("expected %s, got %s", describeExpect(expect), describe(outcome))
+		case !expect.Success && expect.ErrorCode != 0 && outcome.ErrorCode != expect.ErrorCode:
+			return fmt.Sprintf("expected %s, got %s", describeExpect(expect), describe(outcome))
+		}
+	}
+	if expect.MessageContains != "" && !strings.Contains(result.Message, expect.MessageContains) {
+		return fmt.Sprintf("expected the message to contain %q, got %q", expect.MessageContains, result.Message)
+	}
+
+	return ""
+}
+
+func describe(o capture.Outcome) string {
+	switch {
+	case o.Status != "":
+		return "status " + o.Status
+	case o.IsSuccess:
+		return "success"
+	default:
+		return fmt.Sprintf("error code %d", o.ErrorCode)
+	}
+}
+
+func describeExpect(e Expect) string {
+	switch {
+	case e.Success:
+		return "success"
+	case e.ErrorCode != 0:
+		return fmt.Sprintf("error code %d", e.ErrorCode)
+	default:
+		return "a rejection"
+	}
+}
+
+// nowInterceptor lets the cases set the current time of the rules, as admins
+// do with the Evaluate method of the admin service.
+func nowInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := server.ContextWithNowFromMetadata(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return handler(ctx, req)
+}
+
+// tokenValidator only accepts Token.
+type tokenValidator struct{}
+
+func (tokenValidator) Initialize(...context.Context) error {
+	return nil
+}
+
+func (tokenValidator) Validate(token string, _ *iam.Permission, _ *string, _ *string) error {
+	if token != Token {
+		return errors.New("invalid token")
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package ruletest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/server"
+)
+
+// RunSuite runs the cases of suite against a validator with the rule set of
+// the suite, configured by opts.
+func RunSuite(ctx context.Context, suite *Suite, opts ...server.Option) ([]*Result, error) {
+	validator := server.NewCloudsaveValidationServiceServer(opts...)
+	if path := suite.RuleSetPath(); path != "" {
+		config, err := server.LoadRuleSetConfig(path)
+		if err == nil {
+			err = validator.LoadRuleSet(config)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: rule set %s: %w", suite.Path, path, err)
+		}
+	}
+
+	harness, err := NewHarness(validator)
+	if err != nil {
+		return nil, err
+	}
+	defer harness.Close()
+
+	results := make([]*Result, 0, len(suite.Cases))
+	for _, c := range suite.Cases {
+		results = append(results, harness.Run(ctx, c))
+	}
+
+	return results, nil
+}
+
+// RunFiles runs the cases of the test case files, or directories, of paths as
+// subtests of t named after the file and the case.
+func RunFiles(t *testing.T, paths ...string) {
+	t.Helper()
+
+	suites, err := LoadSuites(paths...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, suite := range suites {
+		results, err := RunSuite(context.Background(), suite)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, result := range results {
+			t.Run(suite.Path+"/"+result.Case.Name, func(t *testing.T) {
+				if !result.Passed() {
+					t.Error(result.Failure)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+// Package ruletest runs declarative rule test cases against a
+// CloudsaveValidatorServer through a gRPC connection, with the auth
+// interceptors enabled.
+package ruletest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"gopkg.in/yaml.v3"
+)
+
+// Suite is a test case file.
+type Suite struct {
+	// Path is the file the suite was loaded from.
+	Path string `yaml:"-" json:"-"`
+	// RuleSet is the rule set file the cases are evaluated with, relative to
+	// the suite file. The built-in rules are used when it is empty.
+	RuleSet string `yaml:"ruleSet,omitempty" json:"ruleSet,omitempty"`
+	Cases   []Case `yaml:"cases" json:"cases"`
+}
+
+// Case is a record sent to a hook and its expected result.
+type Case struct {
+	Name string `yaml:"name" json:"name"`
+	// Hook is the name of the CloudsaveValidatorService method, e.g.
+	// BeforeWriteGameRecord. Bulk hooks receive the record alone.
+	Hook string `yaml:"hook" json:"hook"`
+	// Record holds the fields of the request record, named as in the proto
+	// definition, e.g. key, userId or binaryInfo. A payload given as an object
+	// is sent as JSON, a payload given as a string is sent as is.
+	Record map[string]any `yaml:"record" json:"record"`
+	// Locale is sent as the x-locale metadata.
+	Locale string `yaml:"locale,omitempty" json:"locale,omitempty"`
+	// Now is the current time of the rules, in RFC 3339, e.g.
+	// 2024-03-01T04:00:00+09:00. The time of the host is used when it is empty.
+	Now    string `yaml:"now,omitempty" json:"now,omitempty"`
+	Expect Expect `yaml:"expect" json:"expect"`
+}
+
+// Expect is the expected result of a case. When Status is set, the call is
+// expected to fail with that gRPC status, e.g. INVALID_ARGUMENT.
+type Expect struct {
+	Success   bool   `yaml:"success" json:"success"`
+	ErrorCode int32  `yaml:"errorCode,omitempty" json:"errorCode,omitempty"`
+	Status    string `yaml:"status,omitempty" json:"status,omitempty"`
+	// MessageContains is a substring expected in the error message.
+	MessageContains string `yaml:"messageContains,omitempty" json:"messageContains,omitempty"`
+}
+
+// LoadSuite reads a YAML or JSON test case file.
+func LoadSuite(path string) (*Suite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	suite := &Suite{}
+	if err = yaml.Unmarshal(data, suite); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	suite.Path = path
+	for i, c := range suite.Cases {
+		if c.Name == "" {
+			suite.Cases[i].Name = fmt.Sprintf("case %d", i+1)
+		}
+		if c.Hook == "" {
+			return nil, fmt.Errorf("%s: %s: hook is required", path, suite.Cases[i].Name)
+		}
+		if c.Expect.Status != "" {
+			if _, err = parseStatus(c.Expect.Status); err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", path, suite.Cases[i].Name, err)
+			}
+		}
+	}
+
+	return suite, nil
+}
+
+// LoadSuites loads the test case files of paths, a directory path loads every
+// *.yaml, *.yml and *.json file it contains.
+func LoadSuites(paths ...string) ([]*Suite, error) {
+	var suites []*Suite
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			suite, err := LoadSuite(path)
+			if err != nil {
+				return nil, err
+			}
+			suites = append(suites, suite)
+
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(p string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			switch filepath.Ext(p) {
+			case ".yaml", ".yml", ".json":
+			default:
+				return nil
+			}
+			suite, err := LoadSuite(p)
+			if err != nil {
+				return err
+			}
+			suites = append(suites, suite)
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return suites, nil
+}
+
+// RuleSetPath returns the path of the rule set file of the suite, or "" for
+// the built-in rules.
+func (s *Suite) RuleSetPath() string {
+	if s.RuleSet == "" || filepath.IsAbs(s.RuleSet) {
+		return s.RuleSet
+	}
+
+	return filepath.Join(filepath.Dir(s.Path), s.RuleSet)
+}
+
+// recordJSON returns the protojson encoding of the record of c.
+func (c *Case) recordJSON() ([]byte, error) {
+	record := make(map[string]any, len(c.Record))
+	for name, value := range c.Record {
+		record[name] = value
+	}
+
+	if payload, found := record["payload"]; found {
+		raw, ok := payload.(string)
+		if !ok {
+			data, err := json.Marshal(payload)
+			if err != nil {
+				return nil, fmt.Errorf("payload: %w", err)
+			}
+			raw = string(data)
+		}
+		record["payload"] = base64.StdEncoding.EncodeToString([]byte(raw))
+	}
+
+	return json.Marshal(record)
+}
+
+// parseStatus accepts the INVALID_ARGUMENT and InvalidArgument forms.
+func parseStatus(s string) (codes.Code, error) {
+	var code codes.Code
+	if err := code.UnmarshalJSON([]byte(`"` + strings.ToUpper(s) + `"`)); err == nil {
+		return code, nil
+	}
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		if strings.EqualFold(c.String(), s) {
+			return c, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid status %q", s)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/audit"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/common"
+)
+
+// auditRejection records an audit event for each rule that rejected the
+// record. Binary records have no payload hash, their content is not sent to
+// the validator.
+func (s *CloudsaveValidatorServer) auditRejection(ctx context.Context, record *Record, violations []ruleViolation) {
+	if s.audit == nil {
+		return
+	}
+
+	var payloadHash, clientID string
+	if record.BinaryInfo == nil {
+		hash := sha256.Sum256(record.Payload)
+		payloadHash = hex.EncodeToString(hash[:])
+	}
+	if claims := common.TokenClaimsFromContext(ctx); claims != nil {
+		clientID = claims.ClientID
+	} else {
+		// authorized with a client certificate
+		clientID = common.PeerIdentityFromContext(ctx)
+	}
+
+	now := time.Now().UTC()
+	for _, rv := range violations {
+		outcome := "recorded"
+		if !s.audit.Record(audit.Event{
+			Time:        now,
+			Namespace:   record.Namespace,
+			UserID:      record.UserID,
+			Key:         record.Key,
+			Hook:        string(record.Hook),
+			Rule:        rv.rule.Name,
+			ErrorCode:   rv.violation.Entry.Code,
+			ErrorName:   rv.violation.Entry.Name,
+			PayloadHash: payloadHash,
+			ClientID:    clientID,
+		}) {
+			outcome = "dropped"
+		}
+		s.metrics.auditEvents.WithLabelValues(outcome).Inc()
+	}
+}
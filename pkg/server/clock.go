@@ -0,0 +1,97 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+	// the time zones of the namespaces are embedded, the image has none
+	_ "time/tzdata"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// NowMetadataKey is the metadata overriding the current time of an admin
+// evaluation, in RFC 3339, so QA can check the time-based rules at any date.
+const NowMetadataKey = "x-debug-now"
+
+// Clock tells the current time to the time-based rules.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock is a Clock always telling the same time.
+type FixedClock time.Time
+
+func (c FixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+type nowKey struct{}
+
+// ContextWithNow returns a context under which the rules take now as the
+// current time, instead of the time of the server clock.
+func ContextWithNow(ctx context.Context, now time.Time) context.Context {
+	return context.WithValue(ctx, nowKey{}, now)
+}
+
+// ContextWithNowFromMetadata applies the time of the NowMetadataKey metadata
+// of the incoming call, if any, with ContextWithNow. It must only be used
+// for calls whose caller is allowed to change the time, e.g. admins.
+func ContextWithNowFromMetadata(ctx context.Context) (context.Context, error) {
+	values := metadata.ValueFromIncomingContext(ctx, NowMetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return ctx, nil
+	}
+	now, err := time.Parse(time.RFC3339, values[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", NowMetadataKey, err)
+	}
+
+	return ContextWithNow(ctx, now), nil
+}
+
+// now returns the current time of the call.
+func (s *CloudsaveValidatorServer) now(ctx context.Context) time.Time {
+	if now, found := ctx.Value(nowKey{}).(time.Time); found {
+		return now
+	}
+
+	return s.clock.Now()
+}
+
+// DayConfig is when the days of a namespace start, for the daily rules.
+type DayConfig struct {
+	Location  *time.Location
+	ResetHour int
+}
+
+func (d DayConfig) location() *time.Location {
+	if d.Location == nil {
+		return time.UTC
+	}
+
+	return d.Location
+}
+
+// day returns the day of t, as the date of its day start.
+func (d DayConfig) day(t time.Time) time.Time {
+	location := d.location()
+	t = t.In(location).Add(-time.Duration(d.ResetHour) * time.Hour)
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location)
+}
+
+// sameDay reports whether t1 and t2 are in the same day.
+func (d DayConfig) sameDay(t1, t2 time.Time) bool {
+	return d.day(t1).Equal(d.day(t2))
+}
@@ -6,303 +6,288 @@ package server
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
-	"strconv"
-	"strings"
-	"time"
+	"sync"
+	"sync/atomic"
 
+	"cloudsave-validator-grpc-plugin-server-go/pkg/audit"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/i18n"
 	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/quarantine"
 )
 
 type CloudsaveValidatorServer struct {
 	pb.UnimplementedCloudsaveValidatorServiceServer
+
+	ruleSet    atomic.Pointer[RuleSet]
+	ruleSetMu  sync.Mutex
+	ruleSetErr error
+	shadowMode atomic.Bool
+	metrics    *Metrics
+	clock      Clock
+
+	reportFormat          ReportFormat
+	maxReportedViolations int
+	maxEventBannerKB      int
+	translator            *i18n.Translator
+	audit                 *audit.Logger
+	quarantine            *quarantine.Quarantine
 }
 
 func (s *CloudsaveValidatorServer) BeforeWriteGameRecord(ctx context.Context, request *pb.GameRecord) (*pb.GameRecordValidationResult, error) {
-	if strings.HasSuffix(request.GetKey(), "map") {
-		var r CustomGameRecord
-		err := json.Unmarshal(request.GetPayload(), &r)
-		if err != nil {
-			return nil, err
-		}
-		if err = r.Validate(); err != nil {
-			errorDetail := &pb.Error{
-				ErrorCode:    1,
-				ErrorMessage: err.Error(),
-			}
-
-			return &pb.GameRecordValidationResult{
-				IsSuccess: false,
-				Key:       request.Key,
-				Error:     errorDetail,
-			}, nil
-		}
+	validationError, err := s.evaluate(ctx, newGameRecord(HookBeforeWriteGameRecord, request))
+	if err != nil {
+		return nil, err
 	}
 
-	return &pb.GameRecordValidationResult{IsSuccess: true, Key: request.Key}, nil
+	return gameRecordResult(request.Key, validationError), nil
 }
 
 func (s *CloudsaveValidatorServer) AfterReadGameRecord(ctx context.Context, gameRecord *pb.GameRecord) (*pb.GameRecordValidationResult, error) {
-	if strings.HasSuffix(gameRecord.Key, "daily_msg") {
-		var r DailyMessage
-		err := json.Unmarshal(gameRecord.GetPayload(), &r)
-		if err != nil {
-			return nil, err
-		}
-		if time.Now().Before(r.AvailableOn) {
-			return &pb.GameRecordValidationResult{
-				IsSuccess: false,
-				Key:       gameRecord.Key,
-				Error: &pb.Error{
-					ErrorCode:    2,
-					ErrorMessage: "not accessible yet",
-				},
-			}, nil
-		}
+	validationError, err := s.evaluate(ctx, newGameRecord(HookAfterReadGameRecord, gameRecord))
+	if err != nil {
+		return nil, err
 	}
 
-	return &pb.GameRecordValidationResult{IsSuccess: true, Key: gameRecord.Key}, nil
+	return gameRecordResult(gameRecord.Key, validationError), nil
 }
 
 func (s *CloudsaveValidatorServer) AfterBulkReadGameRecord(ctx context.Context, gameRecords *pb.BulkGameRecord) (*pb.BulkGameRecordValidationResult, error) {
+	s.observeBulk(ctx, HookAfterBulkReadGameRecord, len(gameRecords.GetGameRecords()))
+
 	result := []*pb.GameRecordValidationResult{}
 	for _, gameRecord := range gameRecords.GetGameRecords() {
-		if strings.HasSuffix(gameRecord.Key, "daily_msg") {
-			var r DailyMessage
-			err := json.Unmarshal(gameRecord.GetPayload(), &r)
-			if err != nil {
-				return nil, err
-			}
-			if time.Now().Before(r.AvailableOn) {
-				result = append(result, &pb.GameRecordValidationResult{
-					IsSuccess: false,
-					Key:       gameRecord.Key,
-					Error: &pb.Error{
-						ErrorCode:    2,
-						ErrorMessage: "not accessible yet",
-					},
-				})
-			} else {
-				result = append(result, &pb.GameRecordValidationResult{
-					IsSuccess: true,
-					Key:       gameRecord.Key,
-				})
-			}
-		} else {
-			result = append(result, &pb.GameRecordValidationResult{
-				IsSuccess: true,
-				Key:       gameRecord.Key,
-			})
+		validationError, err := s.evaluate(ctx, newGameRecord(HookAfterBulkReadGameRecord, gameRecord))
+		if err != nil {
+			return nil, err
 		}
+		result = append(result, gameRecordResult(gameRecord.Key, validationError))
 	}
 
 	return &pb.BulkGameRecordValidationResult{ValidationResults: result}, nil
 }
 
 func (s *CloudsaveValidatorServer) BeforeWritePlayerRecord(ctx context.Context, request *pb.PlayerRecord) (*pb.PlayerRecordValidationResult, error) {
-	if strings.HasSuffix(request.GetKey(), "favourite_weapon") {
-		var r CustomPlayerRecord
-		err := json.Unmarshal(request.GetPayload(), &r)
-		if err != nil {
-			return nil, err
-		}
-		if err = r.Validate(); err != nil {
-			errorDetail := &pb.Error{
-				ErrorCode:    1,
-				ErrorMessage: err.Error(),
-			}
-
-			return &pb.PlayerRecordValidationResult{
-				IsSuccess: false,
-				Key:       request.Key,
-				UserId:    request.UserId,
-				Error:     errorDetail,
-			}, nil
-		}
+	validationError, err := s.evaluate(ctx, newPlayerRecord(HookBeforeWritePlayerRecord, request))
+	if err != nil {
+		return nil, err
 	}
 
-	return &pb.PlayerRecordValidationResult{IsSuccess: true, Key: request.Key, UserId: request.UserId}, nil
+	return playerRecordResult(request.Key, request.UserId, validationError), nil
 }
 
 func (s *CloudsaveValidatorServer) AfterReadPlayerRecord(ctx context.Context, playerRecord *pb.PlayerRecord) (*pb.PlayerRecordValidationResult, error) {
-	return &pb.PlayerRecordValidationResult{IsSuccess: true, Key: playerRecord.Key, UserId: playerRecord.UserId}, nil
+	validationError, err := s.evaluate(ctx, newPlayerRecord(HookAfterReadPlayerRecord, playerRecord))
+	if err != nil {
+		return nil, err
+	}
+
+	return playerRecordResult(playerRecord.Key, playerRecord.UserId, validationError), nil
 }
 
 func (s *CloudsaveValidatorServer) AfterBulkReadPlayerRecord(ctx context.Context, playerRecords *pb.BulkPlayerRecord) (*pb.BulkPlayerRecordValidationResult, error) {
+	s.observeBulk(ctx, HookAfterBulkReadPlayerRecord, len(playerRecords.GetPlayerRecords()))
+
 	result := []*pb.PlayerRecordValidationResult{}
 
 	for _, record := range playerRecords.GetPlayerRecords() {
-		result = append(result, &pb.PlayerRecordValidationResult{IsSuccess: true, Key: record.Key, UserId: record.UserId})
+		validationError, err := s.evaluate(ctx, newPlayerRecord(HookAfterBulkReadPlayerRecord, record))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, playerRecordResult(record.Key, record.UserId, validationError))
 	}
 
 	return &pb.BulkPlayerRecordValidationResult{ValidationResults: result}, nil
 }
 
 func (s *CloudsaveValidatorServer) BeforeWriteAdminGameRecord(ctx context.Context, request *pb.AdminGameRecord) (*pb.GameRecordValidationResult, error) {
-	if strings.HasSuffix(request.GetKey(), "map") {
-		var r CustomGameRecord
-		err := json.Unmarshal(request.GetPayload(), &r)
-		if err != nil {
-			return nil, err
-		}
-		if err = r.Validate(); err != nil {
-			errorDetail := &pb.Error{
-				ErrorCode:    1,
-				ErrorMessage: err.Error(),
-			}
-
-			return &pb.GameRecordValidationResult{
-				IsSuccess: false,
-				Key:       request.Key,
-				Error:     errorDetail,
-			}, nil
-		}
+	validationError, err := s.evaluate(ctx, newAdminGameRecord(HookBeforeWriteAdminGameRecord, request))
+	if err != nil {
+		return nil, err
 	}
 
-	return &pb.GameRecordValidationResult{IsSuccess: true, Key: request.Key}, nil
+	return gameRecordResult(request.Key, validationError), nil
 }
 
 func (s *CloudsaveValidatorServer) BeforeWriteAdminPlayerRecord(ctx context.Context, request *pb.AdminPlayerRecord) (*pb.PlayerRecordValidationResult, error) {
-	if strings.HasSuffix(request.GetKey(), "player_activity") {
-		var r PlayerActivity
-		err := json.Unmarshal(request.GetPayload(), &r)
-		if err != nil {
-			return nil, err
-		}
-		if err = r.Validate(); err != nil {
-			errorDetail := &pb.Error{
-				ErrorCode:    1,
-				ErrorMessage: err.Error(),
-			}
-
-			return &pb.PlayerRecordValidationResult{
-				IsSuccess: false,
-				Key:       request.Key,
-				UserId:    request.UserId,
-				Error:     errorDetail,
-			}, nil
-		}
+	validationError, err := s.evaluate(ctx, newAdminPlayerRecord(HookBeforeWriteAdminPlayerRecord, request))
+	if err != nil {
+		return nil, err
 	}
 
-	return &pb.PlayerRecordValidationResult{IsSuccess: true, Key: request.Key, UserId: request.UserId}, nil
+	return playerRecordResult(request.Key, request.UserId, validationError), nil
 }
 
 func (s *CloudsaveValidatorServer) BeforeWriteGameBinaryRecord(ctx context.Context, request *pb.GameBinaryRecord) (*pb.GameRecordValidationResult, error) {
-	if strings.HasSuffix(request.GetKey(), "event_banner") && request.GetBinaryInfo() != nil {
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, request.GetBinaryInfo().GetUrl(), nil)
-		if err != nil {
-			return nil, err
-		}
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-
-		fileSize, err := strconv.Atoi(resp.Header.Get("Content-Length"))
-		if err != nil {
-			return nil, err
-		}
-
-		if fileSize/1000 > MaxSizeEventBannerInKB {
-			errorDetail := &pb.Error{
-				ErrorCode:    1,
-				ErrorMessage: fmt.Sprintf("maximum size for event banner is %d kB", MaxSizeEventBannerInKB),
-			}
-
-			return &pb.GameRecordValidationResult{
-				IsSuccess: false,
-				Key:       request.Key,
-				Error:     errorDetail,
-			}, nil
-		}
+	validationError, err := s.evaluate(ctx, newGameBinaryRecord(HookBeforeWriteGameBinaryRecord, request))
+	if err != nil {
+		return nil, err
 	}
 
-	return &pb.GameRecordValidationResult{IsSuccess: true, Key: request.Key}, nil
+	return gameRecordResult(request.Key, validationError), nil
 }
 
 func (s *CloudsaveValidatorServer) AfterReadGameBinaryRecord(ctx context.Context, request *pb.GameBinaryRecord) (*pb.GameRecordValidationResult, error) {
-	if strings.HasSuffix(request.GetKey(), "daily_event_stage") && request.GetBinaryInfo() != nil {
-		if !isSameDate(time.Now().UTC(), request.GetBinaryInfo().GetUpdatedAt().AsTime().UTC()) {
-			errorDetail := &pb.Error{
-				ErrorCode:    1,
-				ErrorMessage: fmt.Sprintf("today's %s is not ready yet", request.Key),
-			}
-
-			return &pb.GameRecordValidationResult{
-				IsSuccess: false,
-				Key:       request.Key,
-				Error:     errorDetail,
-			}, nil
-		}
+	validationError, err := s.evaluate(ctx, newGameBinaryRecord(HookAfterReadGameBinaryRecord, request))
+	if err != nil {
+		return nil, err
 	}
 
-	return &pb.GameRecordValidationResult{IsSuccess: true, Key: request.Key}, nil
+	return gameRecordResult(request.Key, validationError), nil
 }
 
 func (s *CloudsaveValidatorServer) AfterBulkReadGameBinaryRecord(ctx context.Context, request *pb.BulkGameBinaryRecord) (*pb.BulkGameRecordValidationResult, error) {
+	s.observeBulk(ctx, HookAfterBulkReadGameBinaryRecord, len(request.GetGameBinaryRecords()))
+
 	result := []*pb.GameRecordValidationResult{}
 
 	for _, record := range request.GetGameBinaryRecords() {
-		if strings.HasSuffix(record.GetKey(), "daily_event_stage") && record.GetBinaryInfo() != nil {
-			if !isSameDate(time.Now().UTC(), record.GetBinaryInfo().GetUpdatedAt().AsTime().UTC()) {
-				errorDetail := &pb.Error{
-					ErrorCode:    1,
-					ErrorMessage: fmt.Sprintf("today's %s is not ready yet", record.Key),
-				}
-
-				result = append(result, &pb.GameRecordValidationResult{
-					IsSuccess: false,
-					Key:       record.Key,
-					Error:     errorDetail,
-				})
-			} else {
-				result = append(result, &pb.GameRecordValidationResult{IsSuccess: true, Key: record.Key})
-			}
-		} else {
-			result = append(result, &pb.GameRecordValidationResult{IsSuccess: true, Key: record.Key})
+		validationError, err := s.evaluate(ctx, newGameBinaryRecord(HookAfterBulkReadGameBinaryRecord, record))
+		if err != nil {
+			return nil, err
 		}
+		result = append(result, gameRecordResult(record.Key, validationError))
 	}
 
 	return &pb.BulkGameRecordValidationResult{ValidationResults: result}, nil
 }
 
 func (s *CloudsaveValidatorServer) BeforeWritePlayerBinaryRecord(ctx context.Context, request *pb.PlayerBinaryRecord) (*pb.PlayerRecordValidationResult, error) {
-	if strings.HasSuffix(request.GetKey(), "id_card") && request.GetBinaryInfo() != nil {
-		if request.GetBinaryInfo().GetVersion() > 1 {
-			errorDetail := &pb.Error{
-				ErrorCode:    1,
-				ErrorMessage: "id card can only be created once",
-			}
-
-			return &pb.PlayerRecordValidationResult{
-				IsSuccess: false,
-				Key:       request.Key,
-				UserId:    request.UserId,
-				Error:     errorDetail,
-			}, nil
-		}
+	validationError, err := s.evaluate(ctx, newPlayerBinaryRecord(HookBeforeWritePlayerBinaryRecord, request))
+	if err != nil {
+		return nil, err
 	}
 
-	return &pb.PlayerRecordValidationResult{IsSuccess: true, Key: request.Key, UserId: request.UserId}, nil
+	return playerRecordResult(request.Key, request.UserId, validationError), nil
 }
 
 func (s *CloudsaveValidatorServer) AfterReadPlayerBinaryRecord(ctx context.Context, request *pb.PlayerBinaryRecord) (*pb.PlayerRecordValidationResult, error) {
-	return &pb.PlayerRecordValidationResult{IsSuccess: true, Key: request.Key, UserId: request.UserId}, nil
+	validationError, err := s.evaluate(ctx, newPlayerBinaryRecord(HookAfterReadPlayerBinaryRecord, request))
+	if err != nil {
+		return nil, err
+	}
+
+	return playerRecordResult(request.Key, request.UserId, validationError), nil
 }
 
 func (s *CloudsaveValidatorServer) AfterBulkReadPlayerBinaryRecord(ctx context.Context, request *pb.BulkPlayerBinaryRecord) (*pb.BulkPlayerRecordValidationResult, error) {
+	s.observeBulk(ctx, HookAfterBulkReadPlayerBinaryRecord, len(request.GetPlayerBinaryRecords()))
+
 	result := []*pb.PlayerRecordValidationResult{}
 
 	for _, record := range request.GetPlayerBinaryRecords() {
-		result = append(result, &pb.PlayerRecordValidationResult{IsSuccess: true, Key: record.Key, UserId: record.UserId})
+		validationError, err := s.evaluate(ctx, newPlayerBinaryRecord(HookAfterBulkReadPlayerBinaryRecord, record))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, playerRecordResult(record.Key, record.UserId, validationError))
 	}
 
 	return &pb.BulkPlayerRecordValidationResult{ValidationResults: result}, nil
 }
 
-func NewCloudsaveValidationServiceServer() *CloudsaveValidatorServer {
-	return &CloudsaveValidatorServer{}
+// RuleSet returns the rule set currently in use.
+func (s *CloudsaveValidatorServer) RuleSet() *RuleSet {
+	return s.ruleSet.Load()
+}
+
+// LoadRuleSet builds a rule set from the built-in rules and the given
+// configuration and, when valid, replaces the rule set in use.
+func (s *CloudsaveValidatorServer) LoadRuleSet(config *RuleSetConfig) error {
+	s.ruleSetMu.Lock()
+	defer s.ruleSetMu.Unlock()
+
+	s.ruleSetErr = s.loadRuleSet(config)
+
+	return s.ruleSetErr
+}
+
+// CheckRuleSet returns the error of the last LoadRuleSet, when it failed and
+// the previous rule set is still in use.
+func (s *CloudsaveValidatorServer) CheckRuleSet(_ context.Context) error {
+	s.ruleSetMu.Lock()
+	defer s.ruleSetMu.Unlock()
+
+	if s.ruleSetErr != nil {
+		return fmt.Errorf("rule set %q still in use, the last load failed: %w", s.RuleSet().Version, s.ruleSetErr)
+	}
+
+	return nil
+}
+
+func (s *CloudsaveValidatorServer) loadRuleSet(config *RuleSetConfig) error {
+	schedules, err := s.scheduleRules(config)
+	if err != nil {
+		return err
+	}
+	ttlPolicies, err := s.ttlPolicyRules(config)
+	if err != nil {
+		return err
+	}
+	rules := append(s.builtinRules(), schedules...)
+	ruleSet, err := newRuleSet(append(rules, ttlPolicies...), config)
+	if err != nil {
+		return err
+	}
+	s.ruleSet.Store(ruleSet)
+
+	return nil
+}
+
+// SetRuleMode changes the mode of the rule named name in the rule set in use,
+// or disables it when disabled is set.
+func (s *CloudsaveValidatorServer) SetRuleMode(name string, mode Mode, disabled bool) error {
+	s.ruleSetMu.Lock()
+	defer s.ruleSetMu.Unlock()
+
+	config := s.RuleSet().Config()
+	found := false
+	for i := range config.Rules {
+		if config.Rules[i].Name == name {
+			config.Rules[i].Mode = string(mode)
+			config.Rules[i].Disabled = disabled
+			found = true
+		}
+	}
+	if !found {
+		config.Rules = append(config.Rules, RuleConfig{Name: name, Mode: string(mode), Disabled: disabled})
+	}
+
+	return s.loadRuleSet(config)
+}
+
+// ShadowMode reports whether every rule is currently forced into shadow mode.
+func (s *CloudsaveValidatorServer) ShadowMode() bool {
+	return s.shadowMode.Load()
+}
+
+// SetShadowMode forces every rule into shadow mode, regardless of its own
+// mode, while enabled.
+func (s *CloudsaveValidatorServer) SetShadowMode(enabled bool) {
+	s.shadowMode.Store(enabled)
+}
+
+// Metrics returns the collectors to register on the Prometheus registry.
+func (s *CloudsaveValidatorServer) Metrics() *Metrics {
+	return s.metrics
+}
+
+func NewCloudsaveValidationServiceServer(opts ...Option) *CloudsaveValidatorServer {
+	s := &CloudsaveValidatorServer{
+		metrics:               NewMetrics(),
+		clock:                 systemClock{},
+		reportFormat:          ReportFormatJSON,
+		maxReportedViolations: 10,
+		maxEventBannerKB:      MaxSizeEventBannerInKB,
+		translator:            i18n.NewTranslator("en"),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if err := s.LoadRuleSet(nil); err != nil {
+		panic(err)
+	}
+
+	return s
 }
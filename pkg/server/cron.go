@@ -0,0 +1,157 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a cron expression of five fields: minute, hour, day of
+// month, month and day of week. Fields are lists of values, ranges and steps,
+// e.g. "0 18 * * fri,sat" or "*/30 9-17 * * 1-5".
+type cronSchedule struct {
+	minutes, hours, days, months, weekdays uint64
+	// as in cron, when both the day of month and the day of week are
+	// restricted, a day matching either of them matches
+	anyDay, anyWeekday bool
+}
+
+var (
+	cronMonths   = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	cronWeekdays = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+)
+
+func parseCron(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	c := &cronSchedule{}
+	var err error
+	if c.minutes, _, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: minute: %w", expr, err)
+	}
+	if c.hours, _, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: hour: %w", expr, err)
+	}
+	if c.days, c.anyDay, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: day of month: %w", expr, err)
+	}
+	if c.months, _, err = parseCronField(fields[3], 1, 12, cronMonths); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: month: %w", expr, err)
+	}
+	if c.weekdays, c.anyWeekday, err = parseCronField(fields[4], 0, 7, cronWeekdays); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: day of week: %w", expr, err)
+	}
+	// 7 is also Sunday
+	if c.weekdays&(1<<7) != 0 {
+		c.weekdays |= 1
+	}
+
+	return c, nil
+}
+
+// parseCronField returns the set of values of field as a bit mask, and
+// whether the field starts with "*".
+func parseCronField(field string, min, max int, names map[string]int) (uint64, bool, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		valueRange, stepText, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepText); err != nil || step <= 0 {
+				return 0, false, fmt.Errorf("invalid step %q", stepText)
+			}
+		}
+
+		first, last := min, max
+		if valueRange != "*" {
+			firstText, lastText, isRange := strings.Cut(valueRange, "-")
+			var err error
+			if first, err = parseCronValue(firstText, min, max, names); err != nil {
+				return 0, false, err
+			}
+			last = first
+			if isRange {
+				if last, err = parseCronValue(lastText, min, max, names); err != nil {
+					return 0, false, err
+				}
+			} else if hasStep {
+				last = max
+			}
+			if last < first {
+				return 0, false, fmt.Errorf("invalid range %q", valueRange)
+			}
+		}
+
+		for v := first; v <= last; v += step {
+			bits |= 1 << v
+		}
+	}
+
+	return bits, strings.HasPrefix(field, "*"), nil
+}
+
+func parseCronValue(s string, min, max int, names map[string]int) (int, error) {
+	if v, found := names[strings.ToLower(s)]; found {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < min || v > max {
+		return 0, fmt.Errorf("invalid value %q, expected %d to %d", s, min, max)
+	}
+
+	return v, nil
+}
+
+func (c *cronSchedule) matchesDay(t time.Time) bool {
+	if c.months&(1<<t.Month()) == 0 {
+		return false
+	}
+	day := c.days&(1<<t.Day()) != 0
+	weekday := c.weekdays&(1<<t.Weekday()) != 0
+	if c.anyDay || c.anyWeekday {
+		return day && weekday
+	}
+
+	return day || weekday
+}
+
+// active reports whether t is less than duration after a time matching the
+// expression, in the location of t.
+func (c *cronSchedule) active(t time.Time, duration time.Duration) bool {
+	location := t.Location()
+	earliest := t.Add(-duration)
+	first := time.Date(earliest.Year(), earliest.Month(), earliest.Day(), 0, 0, 0, 0, location)
+	// the first start not after t, walking back from t, is the latest one
+	for d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location); !d.Before(first); d = d.AddDate(0, 0, -1) {
+		if !c.matchesDay(d) {
+			continue
+		}
+		for h := 23; h >= 0; h-- {
+			if c.hours&(1<<h) == 0 {
+				continue
+			}
+			for m := 59; m >= 0; m-- {
+				if c.minutes&(1<<m) == 0 {
+					continue
+				}
+				start := time.Date(d.Year(), d.Month(), d.Day(), h, m, 0, 0, location)
+				if start.After(t) {
+					continue
+				}
+
+				return t.Before(start.Add(duration))
+			}
+		}
+	}
+
+	return false
+}
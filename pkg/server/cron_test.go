@@ -0,0 +1,95 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronMatchesDay(t *testing.T) {
+	// in March 2024, the 1st and the 15th are Fridays, the 3rd a Sunday
+	date := func(day int) time.Time { return time.Date(2024, 3, day, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		expr string
+		day  int
+		want bool
+	}{
+		// both restricted: either matches
+		{"0 0 13 * fri", 13, true},
+		{"0 0 13 * fri", 15, true},
+		{"0 0 13 * fri", 14, false},
+		// one restricted: only it matters
+		{"0 0 13 * *", 13, true},
+		{"0 0 13 * *", 15, false},
+		{"0 0 * * fri", 15, true},
+		{"0 0 * * fri", 13, false},
+		// as in cron, a field starting with * counts as unrestricted even
+		// with a step, so both must match
+		{"0 0 */2 * mon", 11, true},
+		{"0 0 */2 * mon", 4, false},
+		{"0 0 */2 * mon", 3, false},
+		// 7 is also Sunday
+		{"0 0 * * 7", 3, true},
+		{"0 0 * mar *", 14, true},
+		{"0 0 * apr *", 14, false},
+	}
+	for _, tt := range tests {
+		c, err := parseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("parseCron(%q): %v", tt.expr, err)
+		}
+		if got := c.matchesDay(date(tt.day)); got != tt.want {
+			t.Errorf("%q matches March %d = %v, want %v", tt.expr, tt.day, got, tt.want)
+		}
+	}
+}
+
+func TestCronActive(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := parseCron("0 22 * * fri")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2024, 3, 15, 21, 59, 0, 0, paris), false},
+		{time.Date(2024, 3, 15, 22, 0, 0, 0, paris), true},
+		// the window of Friday runs over midnight
+		{time.Date(2024, 3, 16, 1, 59, 0, 0, paris), true},
+		{time.Date(2024, 3, 16, 2, 0, 0, 0, paris), false},
+		{time.Date(2024, 3, 14, 22, 30, 0, 0, paris), false},
+	}
+	for _, tt := range tests {
+		if got := c.active(tt.at, 4*time.Hour); got != tt.want {
+			t.Errorf("active at %s = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestParseCronRejects(t *testing.T) {
+	for _, expr := range []string{
+		"0 18 * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"* * * * sat-mon",
+		"*/0 * * * *",
+		"* * * foo *",
+	} {
+		if _, err := parseCron(expr); err == nil {
+			t.Errorf("parseCron(%q) succeeded", expr)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelCodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/common"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/errorcode"
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+)
+
+var tracer = otel.Tracer("cloudsave-validator-grpc-plugin-server-go/pkg/server")
+
+// evaluate runs every rule matching the record and returns the validation
+// report of the enforced rules that failed as an error, or nil when the record
+// is accepted. Rules in shadow mode are only reported.
+//
+// A rule that cannot be evaluated is handled according to the error policy of
+// the record's key and phase; the returned error is always a gRPC status.
+//
+// Explained evaluations are dry runs of the admin service: they are not
+// audited, quarantined, traced or counted in the metrics.
+func (s *CloudsaveValidatorServer) evaluate(ctx context.Context, record *Record) (*pb.Error, error) {
+	ruleSet := s.RuleSet()
+	explanation := explanationFromContext(ctx)
+	if record.BinaryInfo == nil && explanation == nil {
+		s.metrics.payloadSize.WithLabelValues(string(record.Hook), s.keyLabel(record.Key)).Observe(float64(len(record.Payload)))
+	}
+
+	explained := RecordTrace{Hook: record.Hook, Key: record.Key}
+	defer func() { explanation.add(explained) }()
+
+	var violations []ruleViolation
+	for _, rule := range ruleSet.match(record.Hook, record.Key) {
+		shadow := rule.Mode == ModeShadow || s.ShadowMode()
+
+		var violation *Violation
+		var err error
+		if explanation != nil {
+			var duration time.Duration
+			violation, duration, err = timedCheck(ctx, rule, record)
+			explained.Rules = append(explained.Rules, explainRule(rule, record, shadow, violation, err, duration, ruleSet))
+		} else {
+			violation, err = s.check(ctx, rule, record, shadow)
+		}
+		if err != nil {
+			if shadow {
+				slog.WarnContext(ctx, "shadow rule error", "rule", rule.Name, "hook", string(record.Hook), "key", record.Key, "error", err)
+
+				continue
+			}
+
+			switch ruleSet.errorPolicy(record.Hook.Phase(), record.Key) {
+			case ErrorPolicyFailOpen:
+				slog.WarnContext(ctx, "rule error, failing open", "rule", rule.Name, "hook", string(record.Hook), "key", record.Key, "error", err)
+				if explanation == nil {
+					s.metrics.failOpens.WithLabelValues(string(record.Hook), rule.Name).Inc()
+				}
+
+				continue
+			case ErrorPolicyFailClosed:
+				slog.ErrorContext(ctx, "rule error, failing closed", "rule", rule.Name, "hook", string(record.Hook), "key", record.Key, "error", err)
+				violation = &Violation{
+					Entry:  errorcode.Internal,
+					Params: map[string]any{"key": record.Key, "rule": rule.Name},
+				}
+			default:
+				return nil, ruleErrorStatus(rule, err)
+			}
+		}
+		if violation == nil {
+			continue
+		}
+
+		if shadow {
+			if explanation == nil {
+				s.reportShadowViolation(ctx, rule, record, violation)
+			}
+
+			continue
+		}
+		violations = append(violations, ruleViolation{rule: rule, violation: violation})
+	}
+
+	if len(violations) == 0 {
+		return nil, nil
+	}
+	if record.Hook.Phase() == PhaseWrite && explanation == nil {
+		s.auditRejection(ctx, record, violations)
+		s.quarantineRejection(record, violations)
+	}
+
+	m := messages{translator: s.translator, locale: s.locale(ctx, record.Namespace)}
+
+	return newValidationReport(violations, s.maxReportedViolations, m).toError(s.reportFormat), nil
+}
+
+// check evaluates a single rule in its own span and records its metrics.
+func (s *CloudsaveValidatorServer) check(ctx context.Context, rule *Rule, record *Record, shadow bool) (*Violation, error) {
+	ctx, span := tracer.Start(ctx, "rule "+rule.Name, trace.WithAttributes(
+		attribute.String("rule", rule.Name),
+		attribute.String("rule.version", rule.Version),
+		attribute.String("hook", string(record.Hook)),
+		attribute.String("key", record.Key),
+		attribute.String("namespace", record.Namespace),
+	))
+	defer span.End()
+
+	violation, duration, err := timedCheck(ctx, rule, record)
+	outcome := ruleOutcome(violation, err, shadow)
+	s.observeRule(record, rule, outcome, duration)
+
+	span.SetAttributes(attribute.String("outcome", outcome))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelCodes.Error, err.Error())
+	}
+	if violation != nil {
+		span.SetAttributes(
+			attribute.Int("error_code", int(violation.Entry.Code)),
+			attribute.String("error_name", violation.Entry.Name),
+		)
+	}
+
+	return violation, err
+}
+
+// timedCheck evaluates a single rule and returns how long it took.
+func timedCheck(ctx context.Context, rule *Rule, record *Record) (*Violation, time.Duration, error) {
+	start := time.Now()
+	violation, err := checkRecovered(ctx, rule, record)
+
+	return violation, time.Since(start), err
+}
+
+// checkRecovered returns a panic of the rule as its error, for the error
+// policy to apply instead of the call failing.
+func checkRecovered(ctx context.Context, rule *Rule, record *Record) (violation *Violation, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			violation, err = nil, common.PanicError(ctx, p)
+		}
+	}()
+
+	return rule.Check(ctx, record)
+}
+
+func explainRule(rule *Rule, record *Record, shadow bool, violation *Violation, err error, duration time.Duration, ruleSet *RuleSet) RuleTrace {
+	explained := RuleTrace{
+		Rule:     rule.Name,
+		Version:  rule.Version,
+		Mode:     rule.Mode,
+		Shadow:   shadow,
+		Outcome:  ruleOutcome(violation, err, shadow),
+		Duration: duration,
+	}
+	if violation != nil {
+		explained.ErrorCode = int(violation.Entry.Code)
+		explained.ErrorName = violation.Entry.Name
+		explained.Message = violation.Message()
+	}
+	if err != nil {
+		explained.Error = err.Error()
+		if !shadow {
+			explained.ErrorPolicy = ruleSet.errorPolicy(record.Hook.Phase(), record.Key)
+		}
+	}
+
+	return explained
+}
+
+func ruleOutcome(violation *Violation, err error, shadow bool) string {
+	switch {
+	case err != nil:
+		return OutcomeError
+	case violation == nil:
+		return OutcomePass
+	case shadow:
+		return OutcomeShadowFail
+	default:
+		return OutcomeFail
+	}
+}
+
+func (s *CloudsaveValidatorServer) observeRule(record *Record, rule *Rule, outcome string, duration time.Duration) {
+	labels := []string{string(record.Hook), rule.KeyPattern, rule.Name, outcome}
+	s.metrics.ruleEvaluations.WithLabelValues(labels...).Inc()
+	s.metrics.ruleDuration.WithLabelValues(labels...).Observe(duration.Seconds())
+}
+
+func (s *CloudsaveValidatorServer) reportShadowViolation(ctx context.Context, rule *Rule, record *Record, violation *Violation) {
+	slog.WarnContext(ctx, "shadow rule violation",
+		"rule", rule.Name,
+		"hook", string(record.Hook),
+		"key", record.Key,
+		"namespace", record.Namespace,
+		"userId", record.UserID,
+		"errorCode", violation.Entry.Code,
+		"errorName", violation.Entry.Name,
+		"errorMessage", violation.Message(),
+	)
+
+	s.metrics.shadowViolations.WithLabelValues(rule.Name, s.keyLabel(record.Key)).Inc()
+
+	trace.SpanFromContext(ctx).AddEvent("shadow_violation", trace.WithAttributes(
+		attribute.String("rule", rule.Name),
+		attribute.String("key", record.Key),
+		attribute.Int("error_code", int(violation.Entry.Code)),
+		attribute.String("error_name", violation.Entry.Name),
+		attribute.String("error_message", violation.Message()),
+	))
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Explanation collects how every record of a call was evaluated. It is only
+// filled for calls whose context was returned by ContextWithExplanation.
+type Explanation struct {
+	mu      sync.Mutex
+	records []RecordTrace
+}
+
+// RecordTrace lists the rules that matched a record, in evaluation order.
+type RecordTrace struct {
+	Hook  Hook
+	Key   string
+	Rules []RuleTrace
+}
+
+// RuleTrace is the result of a single rule on a record.
+type RuleTrace struct {
+	Rule     string
+	Version  string
+	Mode     Mode
+	Shadow   bool
+	Outcome  string
+	Duration time.Duration
+
+	// set when the rule failed
+	ErrorCode int
+	ErrorName string
+	Message   string
+
+	// set when the rule returned an error
+	Error       string
+	ErrorPolicy ErrorPolicy
+}
+
+type explanationKey struct{}
+
+// ContextWithExplanation returns a context under which the server records
+// the evaluation of every rule into the returned explanation.
+func ContextWithExplanation(ctx context.Context) (context.Context, *Explanation) {
+	explanation := &Explanation{}
+
+	return context.WithValue(ctx, explanationKey{}, explanation), explanation
+}
+
+func explanationFromContext(ctx context.Context) *Explanation {
+	explanation, _ := ctx.Value(explanationKey{}).(*Explanation)
+
+	return explanation
+}
+
+// Records returns the records evaluated so far.
+func (e *Explanation) Records() []RecordTrace {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]RecordTrace(nil), e.records...)
+}
+
+func (e *Explanation) add(record RecordTrace) {
+	if e == nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.records = append(e.records, record)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/common"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/errorcode"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/i18n"
+)
+
+// LocaleMetadataKey is the gRPC metadata key a caller can use to choose the
+// locale of the messages, before falling back to accept-language.
+const LocaleMetadataKey = "x-locale"
+
+// messages renders violation messages in a locale, falling back to the
+// templates of the error code catalog.
+type messages struct {
+	translator *i18n.Translator
+	locale     string
+}
+
+// locale returns the locale requested in the metadata, then the locale claim
+// of the access token, then the locale of the record's namespace, then the
+// default locale.
+func (s *CloudsaveValidatorServer) locale(ctx context.Context, namespace string) string {
+	if meta, found := metadata.FromIncomingContext(ctx); found {
+		if values := meta.Get(LocaleMetadataKey); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+		if values := meta.Get("accept-language"); len(values) > 0 {
+			if locale := i18n.ParseAcceptLanguage(values[0]); locale != "" {
+				return locale
+			}
+		}
+	}
+
+	if claims := common.TokenClaimsFromContext(ctx); claims != nil && claims.Locale != "" {
+		return claims.Locale
+	}
+
+	if locale := s.RuleSet().Namespaces[namespace].Locale; locale != "" {
+		return locale
+	}
+
+	return s.translator.DefaultLocale()
+}
+
+func (m messages) violation(v *Violation) string {
+	if template, found := m.translator.Lookup(m.locale, v.Entry.Name); found {
+		return errorcode.Interpolate(template, v.Params)
+	}
+
+	return v.Message()
+}
+
+// field renders the message of a field violation with the template named
+// after the error code and the constraint, e.g. SCHEMA_INVALID.required.
+func (m messages) field(v *Violation, f FieldViolation) string {
+	template, found := m.translator.Lookup(m.locale, v.Entry.Name+"."+f.Constraint)
+	if !found {
+		return f.Message
+	}
+
+	params := make(map[string]any, len(v.Params)+1)
+	for name, value := range v.Params {
+		params[name] = value
+	}
+	params["field"] = f.Field
+
+	return errorcode.Interpolate(template, params)
+}
+
+// omitted renders the note appended to a report summary when violations were
+// left out, with the OMITTED_VIOLATIONS template.
+func (m messages) omitted(count int) string {
+	template, found := m.translator.Lookup(m.locale, "OMITTED_VIOLATIONS")
+	if !found {
+		template = "(and {count} more)"
+	}
+
+	return errorcode.Interpolate(template, map[string]any{"count": count})
+}
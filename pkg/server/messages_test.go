@@ -0,0 +1,54 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/errorcode"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/i18n"
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+)
+
+func TestBundlesTranslateEveryCode(t *testing.T) {
+	// without a default bundle, lookups do not fall back to another locale
+	translator, err := i18n.LoadDir("../../locales", "none")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, locale := range translator.Locales() {
+		for _, entry := range errorcode.All() {
+			if _, found := translator.Lookup(locale, entry.Name); !found {
+				t.Errorf("bundle %s has no template for %s", locale, entry.Name)
+			}
+		}
+	}
+}
+
+func TestTTLViolationsAreLocalized(t *testing.T) {
+	translator, err := i18n.LoadDir("../../locales", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := &ttlPolicy{presence: TTLRequired, minLifetime: time.Hour}
+	createdAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	record := &Record{
+		Key:       "event_spring",
+		TTLConfig: &pb.TTLConfig{ExpiresAt: timestamppb.New(createdAt.Add(30 * time.Minute)), Action: "DELETE"},
+	}
+	violation := policy.check(record, createdAt)
+	if violation == nil || violation.Entry.Code != errorcode.TTLTooShort.Code {
+		t.Fatalf("expected %s, got %+v", errorcode.TTLTooShort.Name, violation)
+	}
+
+	got := messages{translator: translator, locale: "id"}.violation(violation)
+	if want := "ttl event_spring minimal 1h0m0s"; got != want {
+		t.Errorf("message = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/common"
+)
+
+const metricsNamespace = "cloudsave_validator"
+
+// OtherKeyLabel replaces the record key in metric labels once the key is
+// unknown or too many distinct keys have been seen.
+const OtherKeyLabel = "other"
+
+// Outcome of a rule evaluation, as reported in metrics.
+const (
+	OutcomePass       = "pass"
+	OutcomeFail       = "fail"
+	OutcomeError      = "error"
+	OutcomeShadowFail = "shadow-fail"
+)
+
+// Metrics holds the validator's own Prometheus collectors.
+type Metrics struct {
+	shadowViolations *prometheus.CounterVec
+	failOpens        *prometheus.CounterVec
+	ruleEvaluations  *prometheus.CounterVec
+	ruleDuration     *prometheus.HistogramVec
+	payloadSize      *prometheus.HistogramVec
+	bulkBatchSize    *prometheus.HistogramVec
+	auditEvents      *prometheus.CounterVec
+	quarantined      *prometheus.CounterVec
+
+	keys keyLabels
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		shadowViolations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "shadow_violations_total",
+			Help:      "Number of records that failed a rule evaluated in shadow mode.",
+		}, []string{"rule", "key"}),
+		failOpens: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "fail_open_total",
+			Help:      "Number of records accepted because a rule could not be evaluated.",
+		}, []string{"hook", "rule"}),
+		ruleEvaluations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "rule_evaluations_total",
+			Help:      "Number of rule evaluations by outcome.",
+		}, []string{"hook", "key_pattern", "rule", "outcome"}),
+		ruleDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "rule_evaluation_duration_seconds",
+			Help:      "Time spent evaluating a rule.",
+			Buckets:   []float64{.0001, .0005, .001, .005, .01, .05, .1, .5, 1, 5},
+		}, []string{"hook", "key_pattern", "rule", "outcome"}),
+		payloadSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "payload_size_bytes",
+			Help:      "Size of the record payloads received.",
+			Buckets:   prometheus.ExponentialBuckets(64, 4, 9),
+		}, []string{"hook", "key"}),
+		bulkBatchSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "bulk_batch_size",
+			Help:      "Number of records received in a bulk read hook.",
+			Buckets:   []float64{1, 5, 10, 20, 50, 100, 200, 500},
+		}, []string{"hook"}),
+		auditEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "audit_events_total",
+			Help:      "Number of audit events of rejected records, recorded or dropped because the buffer was full.",
+		}, []string{"outcome"}),
+		quarantined: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "quarantined_records_total",
+			Help:      "Number of rejected records quarantined, or dropped because the quarantine buffer was full.",
+		}, []string{"outcome"}),
+		keys: keyLabels{max: 100},
+	}
+}
+
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.shadowViolations,
+		m.failOpens,
+		m.ruleEvaluations,
+		m.ruleDuration,
+		m.payloadSize,
+		m.bulkBatchSize,
+		m.auditEvents,
+		m.quarantined,
+	}
+}
+
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+// keyLabels is the cardinality guard of the key label: only keys matching a
+// rule get their own label value, up to max distinct keys.
+type keyLabels struct {
+	mu   sync.Mutex
+	seen map[string]struct{}
+	max  int
+}
+
+func (k *keyLabels) label(key string, known bool) string {
+	if !known {
+		return OtherKeyLabel
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, found := k.seen[key]; found {
+		return key
+	}
+	if len(k.seen) >= k.max {
+		return OtherKeyLabel
+	}
+	if k.seen == nil {
+		k.seen = map[string]struct{}{}
+	}
+	k.seen[key] = struct{}{}
+
+	return key
+}
+
+// keyLabel returns the value of the key label for key. Rules matching every
+// key, e.g. ttl_expiry, do not make a key known.
+func (s *CloudsaveValidatorServer) keyLabel(key string) string {
+	known := false
+	for _, rule := range s.RuleSet().Rules {
+		if strings.Trim(rule.KeyPattern, "*") == "" {
+			continue
+		}
+		if common.MatchWildcard(rule.KeyPattern, key) {
+			known = true
+
+			break
+		}
+	}
+
+	return s.metrics.keys.label(key, known)
+}
+
+func (s *CloudsaveValidatorServer) observeBulk(ctx context.Context, hook Hook, size int) {
+	if explanationFromContext(ctx) != nil {
+		return
+	}
+	s.metrics.bulkBatchSize.WithLabelValues(string(hook)).Observe(float64(size))
+}
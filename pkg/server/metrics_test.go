@@ -0,0 +1,77 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+)
+
+// countSeries returns the number of series collected from m.
+func countSeries(m *Metrics) int {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		m.Collect(ch)
+		close(ch)
+	}()
+
+	n := 0
+	for range ch {
+		n++
+	}
+
+	return n
+}
+
+func TestKeyLabel(t *testing.T) {
+	s := NewCloudsaveValidationServiceServer(WithMaxKeyLabels(2))
+
+	// ttl_expiry matches every key, it must not make unknown keys known
+	for _, key := range []string{"junk_1", "junk_2", "junk_3"} {
+		if got := s.keyLabel(key); got != OtherKeyLabel {
+			t.Errorf("keyLabel(%q) = %q, want %q", key, got, OtherKeyLabel)
+		}
+	}
+
+	for _, key := range []string{"town_map", "daily_msg", "town_map"} {
+		if got := s.keyLabel(key); got != key {
+			t.Errorf("keyLabel(%q) = %q, want %q", key, got, key)
+		}
+	}
+	if got := s.keyLabel("favourite_weapon"); got != OtherKeyLabel {
+		t.Errorf("keyLabel past the limit = %q, want %q", got, OtherKeyLabel)
+	}
+}
+
+func TestExplainedEvaluationsAreNotObserved(t *testing.T) {
+	s := NewCloudsaveValidationServiceServer()
+	record := &pb.GameRecord{Key: "town_map", Payload: []byte(`{"name":"Town","totalResources":2,"totalEnemy":1}`)}
+	bulk := &pb.BulkGameRecord{GameRecords: []*pb.GameRecord{record}}
+
+	ctx, explanation := ContextWithExplanation(context.Background())
+	if _, err := s.BeforeWriteGameRecord(ctx, record); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AfterBulkReadGameRecord(ctx, bulk); err != nil {
+		t.Fatal(err)
+	}
+	if len(explanation.Records()) != 2 {
+		t.Fatalf("expected 2 explained records, got %+v", explanation.Records())
+	}
+	if n := countSeries(s.metrics); n != 0 {
+		t.Errorf("explained evaluations recorded %d series", n)
+	}
+
+	if _, err := s.BeforeWriteGameRecord(context.Background(), record); err != nil {
+		t.Fatal(err)
+	}
+	if n := countSeries(s.metrics); n == 0 {
+		t.Error("evaluations recorded no series")
+	}
+}
@@ -42,12 +42,22 @@ type CustomPlayerRecord struct {
 }
 
 func (c *CustomPlayerRecord) Validate() error {
-	_, err := govalidator.ValidateStruct(c)
-	if err != nil {
-		return err
+	var errs govalidator.Errors
+	if _, err := govalidator.ValidateStruct(c); err != nil {
+		if !errors.As(err, &errs) {
+			return err
+		}
+	}
+	if c.FavouriteWeaponType != "" && c.FavouriteWeaponType != WeaponTypeSword && c.FavouriteWeaponType != WeaponTypeGun {
+		errs = append(errs, govalidator.Error{
+			Name:                     "favouriteWeaponType",
+			Err:                      errors.New("invalid weapon type"),
+			CustomErrorMessageExists: true,
+			Validator:                "in",
+		})
 	}
-	if c.FavouriteWeaponType != WeaponTypeSword && c.FavouriteWeaponType != WeaponTypeGun {
-		return errors.New("invalid weapon type")
+	if len(errs) > 0 {
+		return errs
 	}
 
 	return nil
@@ -0,0 +1,77 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"cloudsave-validator-grpc-plugin-server-go/pkg/audit"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/i18n"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/quarantine"
+)
+
+// Option configures a CloudsaveValidatorServer.
+type Option func(s *CloudsaveValidatorServer)
+
+// WithReportFormat sets how validation reports are written to the error
+// message. Defaults to ReportFormatJSON.
+func WithReportFormat(format ReportFormat) Option {
+	return func(s *CloudsaveValidatorServer) {
+		s.reportFormat = format
+	}
+}
+
+// WithMaxReportedViolations caps the number of violations listed in a
+// validation report, 0 means no limit. Defaults to 10.
+func WithMaxReportedViolations(n int) Option {
+	return func(s *CloudsaveValidatorServer) {
+		s.maxReportedViolations = n
+	}
+}
+
+// WithTranslator sets the translated message templates. Without it, messages
+// are the default templates of the error code catalog.
+func WithTranslator(translator *i18n.Translator) Option {
+	return func(s *CloudsaveValidatorServer) {
+		s.translator = translator
+	}
+}
+
+// WithMaxKeyLabels caps the number of distinct record keys used as metric
+// label values, other keys are reported as OtherKeyLabel. Defaults to 100.
+func WithMaxKeyLabels(n int) Option {
+	return func(s *CloudsaveValidatorServer) {
+		s.metrics.keys.max = n
+	}
+}
+
+// WithMaxEventBannerSize sets the maximum size, in KB, of the event banners
+// checked by the event_banner_size rule. Defaults to MaxSizeEventBannerInKB.
+func WithMaxEventBannerSize(kb int) Option {
+	return func(s *CloudsaveValidatorServer) {
+		s.maxEventBannerKB = kb
+	}
+}
+
+// WithClock sets the clock of the time-based rules. Defaults to the system
+// clock.
+func WithClock(clock Clock) Option {
+	return func(s *CloudsaveValidatorServer) {
+		s.clock = clock
+	}
+}
+
+// WithAuditLogger records the records rejected in a write hook to logger.
+func WithAuditLogger(logger *audit.Logger) Option {
+	return func(s *CloudsaveValidatorServer) {
+		s.audit = logger
+	}
+}
+
+// WithQuarantine keeps the payloads of the records rejected in a write hook in
+// q.
+func WithQuarantine(q *quarantine.Quarantine) Option {
+	return func(s *CloudsaveValidatorServer) {
+		s.quarantine = q
+	}
+}
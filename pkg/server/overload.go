@@ -0,0 +1,127 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/errorcode"
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+)
+
+// OverloadPolicy decides the response to the calls over the concurrency
+// limit of their class.
+type OverloadPolicy string
+
+const (
+	// OverloadPolicyReject fails the call with RESOURCE_EXHAUSTED.
+	OverloadPolicyReject OverloadPolicy = "reject"
+	// OverloadPolicyFailOpen accepts the records without validating them.
+	OverloadPolicyFailOpen OverloadPolicy = "fail-open"
+	// OverloadPolicyFailClosed rejects the records with errorcode.RateLimited.
+	OverloadPolicyFailClosed OverloadPolicy = "fail-closed"
+)
+
+func ParseOverloadPolicy(s string) (OverloadPolicy, error) {
+	switch OverloadPolicy(strings.ToLower(s)) {
+	case OverloadPolicyReject:
+		return OverloadPolicyReject, nil
+	case OverloadPolicyFailOpen:
+		return OverloadPolicyFailOpen, nil
+	case OverloadPolicyFailClosed:
+		return OverloadPolicyFailClosed, nil
+	default:
+		return "", fmt.Errorf("invalid overload policy %q", s)
+	}
+}
+
+// Classes of the hooks, which have a concurrency limit each.
+const (
+	MethodClassWrite = "write"
+	MethodClassRead  = "read"
+	MethodClassBulk  = "bulk"
+)
+
+// MethodClass returns the class of a method of the validator service, false
+// for the other methods.
+func MethodClass(fullMethod string) (string, bool) {
+	method, found := strings.CutPrefix(fullMethod, "/"+pb.CloudsaveValidatorService_ServiceDesc.ServiceName+"/")
+	hook := Hook(method)
+	if !found || !slices.Contains(Hooks, hook) {
+		return "", false
+	}
+
+	switch {
+	case hook.Phase() == PhaseWrite:
+		return MethodClassWrite, true
+	case strings.HasPrefix(method, "AfterBulkRead"):
+		return MethodClassBulk, true
+	default:
+		return MethodClassRead, true
+	}
+}
+
+// OverloadedResponse returns the response to req, not evaluated because the
+// server is overloaded, with the fail-open or fail-closed policy.
+func (s *CloudsaveValidatorServer) OverloadedResponse(ctx context.Context, req interface{}, policy OverloadPolicy) (interface{}, error) {
+	result := func(namespace string, key string) *pb.Error {
+		if policy == OverloadPolicyFailOpen {
+			return nil
+		}
+		violation := &Violation{Entry: errorcode.RateLimited, Params: map[string]any{"key": key}}
+		m := messages{translator: s.translator, locale: s.locale(ctx, namespace)}
+		violations := []ruleViolation{{rule: &Rule{Name: "concurrency_limit"}, violation: violation}}
+
+		return newValidationReport(violations, s.maxReportedViolations, m).toError(s.reportFormat)
+	}
+
+	switch r := req.(type) {
+	case *pb.GameRecord:
+		return gameRecordResult(r.GetKey(), result(r.GetNamespace(), r.GetKey())), nil
+	case *pb.AdminGameRecord:
+		return gameRecordResult(r.GetKey(), result(r.GetNamespace(), r.GetKey())), nil
+	case *pb.GameBinaryRecord:
+		return gameRecordResult(r.GetKey(), result(r.GetNamespace(), r.GetKey())), nil
+	case *pb.PlayerRecord:
+		return playerRecordResult(r.GetKey(), r.GetUserId(), result(r.GetNamespace(), r.GetKey())), nil
+	case *pb.AdminPlayerRecord:
+		return playerRecordResult(r.GetKey(), r.GetUserId(), result(r.GetNamespace(), r.GetKey())), nil
+	case *pb.PlayerBinaryRecord:
+		return playerRecordResult(r.GetKey(), r.GetUserId(), result(r.GetNamespace(), r.GetKey())), nil
+	case *pb.BulkGameRecord:
+		results := []*pb.GameRecordValidationResult{}
+		for _, record := range r.GetGameRecords() {
+			results = append(results, gameRecordResult(record.GetKey(), result(record.GetNamespace(), record.GetKey())))
+		}
+
+		return &pb.BulkGameRecordValidationResult{ValidationResults: results}, nil
+	case *pb.BulkGameBinaryRecord:
+		results := []*pb.GameRecordValidationResult{}
+		for _, record := range r.GetGameBinaryRecords() {
+			results = append(results, gameRecordResult(record.GetKey(), result(record.GetNamespace(), record.GetKey())))
+		}
+
+		return &pb.BulkGameRecordValidationResult{ValidationResults: results}, nil
+	case *pb.BulkPlayerRecord:
+		results := []*pb.PlayerRecordValidationResult{}
+		for _, record := range r.GetPlayerRecords() {
+			results = append(results, playerRecordResult(record.GetKey(), record.GetUserId(), result(record.GetNamespace(), record.GetKey())))
+		}
+
+		return &pb.BulkPlayerRecordValidationResult{ValidationResults: results}, nil
+	case *pb.BulkPlayerBinaryRecord:
+		results := []*pb.PlayerRecordValidationResult{}
+		for _, record := range r.GetPlayerBinaryRecords() {
+			results = append(results, playerRecordResult(record.GetKey(), record.GetUserId(), result(record.GetNamespace(), record.GetKey())))
+		}
+
+		return &pb.BulkPlayerRecordValidationResult{ValidationResults: results}, nil
+	default:
+		return nil, fmt.Errorf("no overloaded response for %T", req)
+	}
+}
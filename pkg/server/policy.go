@@ -0,0 +1,118 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/common"
+)
+
+// ErrorPolicy decides what happens to a record when a rule returns an error
+// instead of a result.
+type ErrorPolicy string
+
+const (
+	// ErrorPolicyPropagate returns the error as a gRPC status to CloudSave.
+	ErrorPolicyPropagate ErrorPolicy = "propagate"
+	// ErrorPolicyFailOpen accepts the record as if the rule had passed.
+	ErrorPolicyFailOpen ErrorPolicy = "fail-open"
+	// ErrorPolicyFailClosed rejects the record with errorcode.Internal.
+	ErrorPolicyFailClosed ErrorPolicy = "fail-closed"
+)
+
+func ParseErrorPolicy(s string) (ErrorPolicy, error) {
+	switch ErrorPolicy(strings.ToLower(s)) {
+	case ErrorPolicyPropagate:
+		return ErrorPolicyPropagate, nil
+	case ErrorPolicyFailOpen:
+		return ErrorPolicyFailOpen, nil
+	case ErrorPolicyFailClosed:
+		return ErrorPolicyFailClosed, nil
+	default:
+		return "", fmt.Errorf("invalid error policy %q", s)
+	}
+}
+
+// ErrorPolicyConfig applies Policy to records matching KeyPattern in Phase.
+// An empty key pattern matches every key and an empty phase matches both.
+type ErrorPolicyConfig struct {
+	KeyPattern string `yaml:"keyPattern,omitempty" json:"keyPattern,omitempty"`
+	Phase      string `yaml:"phase,omitempty" json:"phase,omitempty"`
+	Policy     string `yaml:"policy" json:"policy"`
+}
+
+type errorPolicyRule struct {
+	keyPattern string
+	phase      Phase
+	policy     ErrorPolicy
+}
+
+func newErrorPolicyRules(configs []ErrorPolicyConfig) ([]errorPolicyRule, error) {
+	rules := make([]errorPolicyRule, 0, len(configs))
+	for i, c := range configs {
+		policy, err := ParseErrorPolicy(c.Policy)
+		if err != nil {
+			return nil, fmt.Errorf("error policy %d: %w", i, err)
+		}
+
+		phase := Phase(strings.ToLower(c.Phase))
+		if phase != "" && phase != PhaseWrite && phase != PhaseRead {
+			return nil, fmt.Errorf("error policy %d: invalid phase %q", i, c.Phase)
+		}
+
+		keyPattern := c.KeyPattern
+		if keyPattern == "" {
+			keyPattern = "*"
+		}
+
+		rules = append(rules, errorPolicyRule{keyPattern: keyPattern, phase: phase, policy: policy})
+	}
+
+	return rules, nil
+}
+
+// errorPolicy returns the policy of the first matching entry. When none
+// matches, reads fail open so players can still load their records, and
+// writes propagate the error.
+func (rs *RuleSet) errorPolicy(phase Phase, key string) ErrorPolicy {
+	for _, p := range rs.errorPolicies {
+		if (p.phase == "" || p.phase == phase) && common.MatchWildcard(p.keyPattern, key) {
+			return p.policy
+		}
+	}
+	if phase == PhaseRead {
+		return ErrorPolicyFailOpen
+	}
+
+	return ErrorPolicyPropagate
+}
+
+// ruleErrorStatus converts an error returned by a rule into a gRPC status.
+func ruleErrorStatus(rule *Rule, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxError), errors.As(err, &typeError):
+		return status.Errorf(codes.InvalidArgument, "rule %s: invalid payload: %v", rule.Name, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Errorf(codes.DeadlineExceeded, "rule %s: %v", rule.Name, err)
+	case errors.Is(err, context.Canceled):
+		return status.Errorf(codes.Canceled, "rule %s: %v", rule.Name, err)
+	default:
+		return status.Errorf(codes.Internal, "rule %s: %v", rule.Name, err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/errorcode"
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+)
+
+// policyOutcome is what CloudSave gets when a rule cannot be evaluated: a
+// gRPC status code, or an accepted or rejected record when it is OK.
+type policyOutcome struct {
+	code      codes.Code
+	errorCode int32
+}
+
+var (
+	accepted   = policyOutcome{}
+	propagated = policyOutcome{code: codes.InvalidArgument}
+	rejected   = policyOutcome{errorCode: errorcode.Internal.Code}
+)
+
+func TestErrorPolicies(t *testing.T) {
+	// the payloads are not JSON, so map_schema on writes and
+	// daily_msg_availability on reads return an error
+	invalid := []byte(`{"name":`)
+
+	tests := []struct {
+		name     string
+		policies []ErrorPolicyConfig
+		write    policyOutcome
+		read     policyOutcome
+	}{
+		{"default", nil, propagated, accepted},
+		{"propagate", []ErrorPolicyConfig{{Policy: "propagate"}}, propagated, propagated},
+		{"fail-open", []ErrorPolicyConfig{{Policy: "fail-open"}}, accepted, accepted},
+		{"fail-closed", []ErrorPolicyConfig{{Policy: "fail-closed"}}, rejected, rejected},
+		{"by phase", []ErrorPolicyConfig{{Phase: "write", Policy: "fail-closed"}}, rejected, accepted},
+		{"by key", []ErrorPolicyConfig{{KeyPattern: "*daily_msg", Policy: "fail-closed"}, {Policy: "fail-open"}}, accepted, rejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewCloudsaveValidationServiceServer()
+			if err := s.LoadRuleSet(&RuleSetConfig{ErrorPolicies: tt.policies}); err != nil {
+				t.Fatal(err)
+			}
+
+			write, err := s.BeforeWriteGameRecord(context.Background(), &pb.GameRecord{Key: "town_map", Payload: invalid})
+			checkPolicyOutcome(t, "write", write, err, tt.write)
+			read, err := s.AfterReadGameRecord(context.Background(), &pb.GameRecord{Key: "daily_msg", Payload: invalid})
+			checkPolicyOutcome(t, "read", read, err, tt.read)
+		})
+	}
+}
+
+func checkPolicyOutcome(t *testing.T, phase string, result *pb.GameRecordValidationResult, err error, want policyOutcome) {
+	t.Helper()
+
+	var got policyOutcome
+	switch {
+	case err != nil:
+		got.code = status.Code(err)
+	case !result.GetIsSuccess():
+		got.errorCode = result.GetError().GetErrorCode()
+	}
+	if got != want {
+		t.Errorf("%s: got status %s, error code %d, want status %s, error code %d", phase, got.code, got.errorCode, want.code, want.errorCode)
+	}
+}
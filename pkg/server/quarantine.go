@@ -0,0 +1,41 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"time"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/quarantine"
+)
+
+// quarantineRejection keeps the payload, or the binary URL, of a rejected
+// record.
+func (s *CloudsaveValidatorServer) quarantineRejection(record *Record, violations []ruleViolation) {
+	if s.quarantine == nil {
+		return
+	}
+
+	entry := quarantine.Entry{
+		Time:      time.Now(),
+		Namespace: record.Namespace,
+		UserID:    record.UserID,
+		Key:       record.Key,
+		Hook:      string(record.Hook),
+		ErrorCode: violations[0].violation.Entry.Code,
+		Payload:   record.Payload,
+	}
+	for _, rv := range violations {
+		entry.Rules = append(entry.Rules, rv.rule.Name)
+	}
+	if record.BinaryInfo != nil {
+		entry.BinaryURL = record.BinaryInfo.GetUrl()
+	}
+
+	outcome := "stored"
+	if !s.quarantine.Add(entry) {
+		outcome = "dropped"
+	}
+	s.metrics.quarantined.WithLabelValues(outcome).Inc()
+}
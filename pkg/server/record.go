@@ -0,0 +1,106 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+)
+
+// Record is the hook-independent view of a CloudSave record that rules are
+// evaluated against.
+type Record struct {
+	Hook       Hook
+	Key        string
+	Namespace  string
+	UserID     string
+	Payload    []byte
+	BinaryInfo *pb.BinaryInfo
+	CreatedAt  *timestamppb.Timestamp
+	TTLConfig  *pb.TTLConfig
+}
+
+func newGameRecord(hook Hook, r *pb.GameRecord) *Record {
+	return &Record{
+		Hook:      hook,
+		Key:       r.GetKey(),
+		Namespace: r.GetNamespace(),
+		Payload:   r.GetPayload(),
+		CreatedAt: r.GetCreatedAt(),
+		TTLConfig: r.GetTtlConfig(),
+	}
+}
+
+func newPlayerRecord(hook Hook, r *pb.PlayerRecord) *Record {
+	return &Record{
+		Hook:      hook,
+		Key:       r.GetKey(),
+		Namespace: r.GetNamespace(),
+		UserID:    r.GetUserId(),
+		Payload:   r.GetPayload(),
+		CreatedAt: r.GetCreatedAt(),
+	}
+}
+
+func newAdminGameRecord(hook Hook, r *pb.AdminGameRecord) *Record {
+	return &Record{
+		Hook:      hook,
+		Key:       r.GetKey(),
+		Namespace: r.GetNamespace(),
+		Payload:   r.GetPayload(),
+		CreatedAt: r.GetCreatedAt(),
+	}
+}
+
+func newAdminPlayerRecord(hook Hook, r *pb.AdminPlayerRecord) *Record {
+	return &Record{
+		Hook:      hook,
+		Key:       r.GetKey(),
+		Namespace: r.GetNamespace(),
+		UserID:    r.GetUserId(),
+		Payload:   r.GetPayload(),
+		CreatedAt: r.GetCreatedAt(),
+	}
+}
+
+func newGameBinaryRecord(hook Hook, r *pb.GameBinaryRecord) *Record {
+	return &Record{
+		Hook:       hook,
+		Key:        r.GetKey(),
+		Namespace:  r.GetNamespace(),
+		BinaryInfo: r.GetBinaryInfo(),
+		CreatedAt:  r.GetCreatedAt(),
+		TTLConfig:  r.GetTtlConfig(),
+	}
+}
+
+func newPlayerBinaryRecord(hook Hook, r *pb.PlayerBinaryRecord) *Record {
+	return &Record{
+		Hook:       hook,
+		Key:        r.GetKey(),
+		Namespace:  r.GetNamespace(),
+		UserID:     r.GetUserId(),
+		BinaryInfo: r.GetBinaryInfo(),
+		CreatedAt:  r.GetCreatedAt(),
+	}
+}
+
+func gameRecordResult(key string, validationError *pb.Error) *pb.GameRecordValidationResult {
+	return &pb.GameRecordValidationResult{
+		IsSuccess: validationError == nil,
+		Key:       key,
+		Error:     validationError,
+	}
+}
+
+func playerRecordResult(key string, userID string, validationError *pb.Error) *pb.PlayerRecordValidationResult {
+	return &pb.PlayerRecordValidationResult{
+		IsSuccess: validationError == nil,
+		Key:       key,
+		UserId:    userID,
+		Error:     validationError,
+	}
+}
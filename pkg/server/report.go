@@ -0,0 +1,157 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+
+	pb "cloudsave-validator-grpc-plugin-server-go/pkg/pb"
+)
+
+// ReportFormat is how a ValidationReport is written to pb.Error.ErrorMessage.
+type ReportFormat string
+
+const (
+	// ReportFormatJSON writes the report as compact JSON.
+	ReportFormatJSON ReportFormat = "json"
+	// ReportFormatText writes only the human-readable message.
+	ReportFormatText ReportFormat = "text"
+)
+
+func ParseReportFormat(s string) (ReportFormat, error) {
+	switch ReportFormat(strings.ToLower(s)) {
+	case ReportFormatJSON:
+		return ReportFormatJSON, nil
+	case ReportFormatText:
+		return ReportFormatText, nil
+	default:
+		return "", fmt.Errorf("invalid report format %q", s)
+	}
+}
+
+// FieldViolation is a violation of a single payload field.
+type FieldViolation struct {
+	Field      string
+	Constraint string
+	Message    string
+}
+
+// fieldViolations extracts the failing fields from an error returned by
+// govalidator.ValidateStruct, in struct field order.
+func fieldViolations(err error) []FieldViolation {
+	var errs govalidator.Errors
+	if errors.As(err, &errs) {
+		var result []FieldViolation
+		for _, e := range errs {
+			result = append(result, fieldViolations(e)...)
+		}
+
+		return result
+	}
+
+	var e govalidator.Error
+	if errors.As(err, &e) {
+		return []FieldViolation{{
+			Field:      strings.Join(append(append([]string{}, e.Path...), e.Name), "."),
+			Constraint: e.Validator,
+			Message:    e.Error(),
+		}}
+	}
+
+	return nil
+}
+
+// ValidationReport lists every violation found in a record. Message is the
+// human-readable summary of the violations.
+type ValidationReport struct {
+	Message    string              `json:"message"`
+	Violations []ReportedViolation `json:"violations"`
+	Omitted    int                 `json:"omitted,omitempty"`
+}
+
+type ReportedViolation struct {
+	Field      string `json:"field,omitempty"`
+	Rule       string `json:"rule"`
+	Constraint string `json:"constraint,omitempty"`
+	Message    string `json:"message"`
+	Code       int32  `json:"code"`
+}
+
+type ruleViolation struct {
+	rule      *Rule
+	violation *Violation
+}
+
+// newValidationReport builds the report of violations, keeping at most
+// maxViolations entries when maxViolations is positive.
+func newValidationReport(violations []ruleViolation, maxViolations int, m messages) *ValidationReport {
+	report := &ValidationReport{Violations: []ReportedViolation{}}
+	add := func(v ReportedViolation) {
+		if maxViolations > 0 && len(report.Violations) >= maxViolations {
+			report.Omitted++
+
+			return
+		}
+		report.Violations = append(report.Violations, v)
+	}
+
+	for _, rv := range violations {
+		if len(rv.violation.Fields) == 0 {
+			add(ReportedViolation{
+				Rule:    rv.rule.Name,
+				Message: m.violation(rv.violation),
+				Code:    rv.violation.Entry.Code,
+			})
+
+			continue
+		}
+		for _, f := range rv.violation.Fields {
+			add(ReportedViolation{
+				Field:      f.Field,
+				Rule:       rv.rule.Name,
+				Constraint: f.Constraint,
+				Message:    m.field(rv.violation, f),
+				Code:       rv.violation.Entry.Code,
+			})
+		}
+	}
+
+	summary := make([]string, 0, len(report.Violations))
+	for _, v := range report.Violations {
+		summary = append(summary, v.Message)
+	}
+	report.Message = strings.Join(summary, "; ")
+	if report.Omitted > 0 {
+		report.Message += " " + m.omitted(report.Omitted)
+	}
+
+	return report
+}
+
+// toError returns the pb.Error for the report, whose code is the code of the
+// first violation.
+func (r *ValidationReport) toError(format ReportFormat) *pb.Error {
+	validationError := &pb.Error{ErrorMessage: r.Message}
+	if len(r.Violations) > 0 {
+		validationError.ErrorCode = r.Violations[0].Code
+	}
+
+	if format == ReportFormatJSON {
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(r); err == nil {
+			validationError.ErrorMessage = strings.TrimSuffix(buf.String(), "\n")
+		}
+	}
+
+	return validationError
+}
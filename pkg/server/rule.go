@@ -0,0 +1,129 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/common"
+	"cloudsave-validator-grpc-plugin-server-go/pkg/errorcode"
+)
+
+// Hook identifies the CloudSave validator RPC a record is evaluated for.
+type Hook string
+
+const (
+	HookBeforeWriteGameRecord           Hook = "BeforeWriteGameRecord"
+	HookAfterReadGameRecord             Hook = "AfterReadGameRecord"
+	HookAfterBulkReadGameRecord         Hook = "AfterBulkReadGameRecord"
+	HookBeforeWritePlayerRecord         Hook = "BeforeWritePlayerRecord"
+	HookAfterReadPlayerRecord           Hook = "AfterReadPlayerRecord"
+	HookAfterBulkReadPlayerRecord       Hook = "AfterBulkReadPlayerRecord"
+	HookBeforeWriteAdminGameRecord      Hook = "BeforeWriteAdminGameRecord"
+	HookBeforeWriteAdminPlayerRecord    Hook = "BeforeWriteAdminPlayerRecord"
+	HookBeforeWriteGameBinaryRecord     Hook = "BeforeWriteGameBinaryRecord"
+	HookAfterReadGameBinaryRecord       Hook = "AfterReadGameBinaryRecord"
+	HookAfterBulkReadGameBinaryRecord   Hook = "AfterBulkReadGameBinaryRecord"
+	HookBeforeWritePlayerBinaryRecord   Hook = "BeforeWritePlayerBinaryRecord"
+	HookAfterReadPlayerBinaryRecord     Hook = "AfterReadPlayerBinaryRecord"
+	HookAfterBulkReadPlayerBinaryRecord Hook = "AfterBulkReadPlayerBinaryRecord"
+)
+
+// Hooks lists every hook in the order they are declared in the service.
+var Hooks = []Hook{
+	HookBeforeWriteGameRecord,
+	HookAfterReadGameRecord,
+	HookAfterBulkReadGameRecord,
+	HookBeforeWritePlayerRecord,
+	HookAfterReadPlayerRecord,
+	HookAfterBulkReadPlayerRecord,
+	HookBeforeWriteAdminGameRecord,
+	HookBeforeWriteAdminPlayerRecord,
+	HookBeforeWriteGameBinaryRecord,
+	HookAfterReadGameBinaryRecord,
+	HookAfterBulkReadGameBinaryRecord,
+	HookBeforeWritePlayerBinaryRecord,
+	HookAfterReadPlayerBinaryRecord,
+	HookAfterBulkReadPlayerBinaryRecord,
+}
+
+// Phase is either PhaseWrite or PhaseRead.
+type Phase string
+
+const (
+	PhaseWrite Phase = "write"
+	PhaseRead  Phase = "read"
+)
+
+func (h Hook) Phase() Phase {
+	if strings.HasPrefix(string(h), "BeforeWrite") {
+		return PhaseWrite
+	}
+
+	return PhaseRead
+}
+
+// Mode controls whether a failing rule rejects the record.
+type Mode string
+
+const (
+	// ModeEnforce rejects the record when the rule fails.
+	ModeEnforce Mode = "enforce"
+	// ModeShadow only reports the failure, the record is still accepted.
+	ModeShadow Mode = "shadow"
+)
+
+func ParseMode(s string) (Mode, error) {
+	switch Mode(strings.ToLower(s)) {
+	case ModeEnforce:
+		return ModeEnforce, nil
+	case ModeShadow:
+		return ModeShadow, nil
+	default:
+		return "", fmt.Errorf("invalid rule mode %q", s)
+	}
+}
+
+// Violation describes why a record did not pass a rule. Every violation
+// references an entry of the error code catalog, whose message template is
+// rendered with Params. Schema rules also list the failing payload fields.
+type Violation struct {
+	Entry  errorcode.Entry
+	Params map[string]any
+	Fields []FieldViolation
+}
+
+func (v *Violation) Message() string {
+	return v.Entry.Format(v.Params)
+}
+
+// Rule is a single validation applied to records whose key matches KeyPattern
+// on the listed hooks.
+type Rule struct {
+	Name       string
+	Version    string
+	KeyPattern string
+	Hooks      []Hook
+	Mode       Mode
+
+	// Check returns a violation when the record does not pass the rule, or an
+	// error when the rule could not be evaluated.
+	Check func(ctx context.Context, record *Record) (*Violation, error)
+}
+
+func (r *Rule) appliesTo(hook Hook, key string) bool {
+	if !common.MatchWildcard(r.KeyPattern, key) {
+		return false
+	}
+	for _, h := range r.Hooks {
+		if h == hook {
+			return true
+		}
+	}
+
+	return false
+}
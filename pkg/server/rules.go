@@ -0,0 +1,220 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/errorcode"
+)
+
+// builtinRules returns the rules implemented in Go, all in ModeEnforce.
+func (s *CloudsaveValidatorServer) builtinRules() []*Rule {
+	return []*Rule{
+		{
+			Name:       "map_schema",
+			Version:    "1",
+			KeyPattern: "*map",
+			Hooks:      []Hook{HookBeforeWriteGameRecord, HookBeforeWriteAdminGameRecord},
+			Check: func(ctx context.Context, record *Record) (*Violation, error) {
+				var r CustomGameRecord
+				if err := json.Unmarshal(record.Payload, &r); err != nil {
+					return nil, err
+				}
+				if err := r.Validate(); err != nil {
+					return &Violation{
+						Entry:  errorcode.SchemaInvalid,
+						Params: map[string]any{"key": record.Key, "detail": err.Error()},
+						Fields: fieldViolations(err),
+					}, nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			Name:       "favourite_weapon_schema",
+			Version:    "1",
+			KeyPattern: "*favourite_weapon",
+			Hooks:      []Hook{HookBeforeWritePlayerRecord},
+			Check: func(ctx context.Context, record *Record) (*Violation, error) {
+				var r CustomPlayerRecord
+				if err := json.Unmarshal(record.Payload, &r); err != nil {
+					return nil, err
+				}
+				if err := r.Validate(); err != nil {
+					return &Violation{
+						Entry:  errorcode.SchemaInvalid,
+						Params: map[string]any{"key": record.Key, "detail": err.Error()},
+						Fields: fieldViolations(err),
+					}, nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			Name:       "player_activity_schema",
+			Version:    "1",
+			KeyPattern: "*player_activity",
+			Hooks:      []Hook{HookBeforeWriteAdminPlayerRecord},
+			Check: func(ctx context.Context, record *Record) (*Violation, error) {
+				var r PlayerActivity
+				if err := json.Unmarshal(record.Payload, &r); err != nil {
+					return nil, err
+				}
+				if err := r.Validate(); err != nil {
+					return &Violation{
+						Entry:  errorcode.SchemaInvalid,
+						Params: map[string]any{"key": record.Key, "detail": err.Error()},
+						Fields: fieldViolations(err),
+					}, nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			Name:       "daily_msg_availability",
+			Version:    "1",
+			KeyPattern: "*daily_msg",
+			Hooks:      []Hook{HookAfterReadGameRecord, HookAfterBulkReadGameRecord},
+			Check: func(ctx context.Context, record *Record) (*Violation, error) {
+				var r DailyMessage
+				if err := json.Unmarshal(record.Payload, &r); err != nil {
+					return nil, err
+				}
+				if s.now(ctx).Before(r.AvailableOn) {
+					return &Violation{
+						Entry:  errorcode.NotYetAvailable,
+						Params: map[string]any{"key": record.Key, "availableOn": r.AvailableOn},
+					}, nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			Name:       "event_banner_size",
+			Version:    "1",
+			KeyPattern: "*event_banner",
+			Hooks:      []Hook{HookBeforeWriteGameBinaryRecord},
+			Check: func(ctx context.Context, record *Record) (*Violation, error) {
+				if record.BinaryInfo == nil {
+					return nil, nil
+				}
+
+				fileSize, err := fetchContentLength(ctx, record.BinaryInfo.GetUrl())
+				if err != nil {
+					return nil, err
+				}
+
+				if fileSize/1000 > s.maxEventBannerKB {
+					return &Violation{
+						Entry:  errorcode.PayloadTooLarge,
+						Params: map[string]any{"key": record.Key, "limit": s.maxEventBannerKB, "size": fileSize},
+					}, nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			Name:       "daily_event_stage_freshness",
+			Version:    "1",
+			KeyPattern: "*daily_event_stage",
+			Hooks:      []Hook{HookAfterReadGameBinaryRecord, HookAfterBulkReadGameBinaryRecord},
+			Check: func(ctx context.Context, record *Record) (*Violation, error) {
+				if record.BinaryInfo == nil {
+					return nil, nil
+				}
+				day := s.RuleSet().day(record.Namespace)
+				if !day.sameDay(s.now(ctx), record.BinaryInfo.GetUpdatedAt().AsTime()) {
+					return &Violation{
+						Entry:  errorcode.NotReady,
+						Params: map[string]any{"key": record.Key, "updatedAt": record.BinaryInfo.GetUpdatedAt().AsTime()},
+					}, nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			Name:       "ttl_expiry",
+			Version:    "1",
+			KeyPattern: "*",
+			Hooks: []Hook{
+				HookAfterReadGameRecord, HookAfterBulkReadGameRecord,
+				HookAfterReadGameBinaryRecord, HookAfterBulkReadGameBinaryRecord,
+			},
+			Check: func(ctx context.Context, record *Record) (*Violation, error) {
+				expiresAt := record.TTLConfig.GetExpiresAt()
+				if expiresAt == nil || s.now(ctx).Before(expiresAt.AsTime()) {
+					return nil, nil
+				}
+
+				return &Violation{
+					Entry:  errorcode.Expired,
+					Params: map[string]any{"key": record.Key, "expiresAt": expiresAt.AsTime()},
+				}, nil
+			},
+		},
+		{
+			Name:       "id_card_once",
+			Version:    "1",
+			KeyPattern: "*id_card",
+			Hooks:      []Hook{HookBeforeWritePlayerBinaryRecord},
+			Check: func(ctx context.Context, record *Record) (*Violation, error) {
+				if record.BinaryInfo == nil {
+					return nil, nil
+				}
+				if record.BinaryInfo.GetVersion() > 1 {
+					return &Violation{
+						Entry:  errorcode.RecordImmutable,
+						Params: map[string]any{"key": record.Key, "version": record.BinaryInfo.GetVersion()},
+					}, nil
+				}
+
+				return nil, nil
+			},
+		},
+	}
+}
+
+// fetchContentLength requests the binary at url and returns its size in bytes.
+// Errors are gRPC statuses so they can be returned to CloudSave as is.
+func fetchContentLength(ctx context.Context, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid binary url: %v", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return 0, status.FromContextError(ctx.Err()).Err()
+		}
+
+		return 0, status.Errorf(codes.Unavailable, "fetch binary: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, status.Errorf(codes.Unavailable, "fetch binary: unexpected status %s", resp.Status)
+	}
+
+	fileSize, err := strconv.Atoi(resp.Header.Get("Content-Length"))
+	if err != nil {
+		return 0, status.Errorf(codes.FailedPrecondition, "fetch binary: invalid Content-Length: %v", err)
+	}
+
+	return fileSize, nil
+}
@@ -0,0 +1,15 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server_test
+
+import (
+	"testing"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/ruletest"
+)
+
+func TestRuleCases(t *testing.T) {
+	ruletest.RunFiles(t, "testdata/cases")
+}
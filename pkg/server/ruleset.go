@@ -0,0 +1,195 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RuleSetConfig is the rule set file, written in YAML or JSON.
+//
+//	version: "2024-06-01"
+//	rules:
+//	  - name: map_schema
+//	    mode: shadow
+//	  - name: id_card_once
+//	    disabled: true
+//	errorPolicies:
+//	  - keyPattern: "ranked_*"
+//	    phase: read
+//	    policy: propagate
+//	schedules:
+//	  - name: halloween_schedule
+//	    keyPattern: "*halloween_*"
+//	    notAfter: "2024-11-01T04:00:00+09:00"
+//	ttlPolicies:
+//	  - name: event_ttl
+//	    keyPattern: "event_*"
+//	    ttl: required
+//	namespaces:
+//	  mygame:
+//	    locale: ja
+//	    timezone: Asia/Tokyo
+//	    resetHour: 4
+type RuleSetConfig struct {
+	Version       string                     `yaml:"version" json:"version"`
+	Rules         []RuleConfig               `yaml:"rules" json:"rules"`
+	ErrorPolicies []ErrorPolicyConfig        `yaml:"errorPolicies,omitempty" json:"errorPolicies,omitempty"`
+	Schedules     []ScheduleConfig           `yaml:"schedules,omitempty" json:"schedules,omitempty"`
+	TTLPolicies   []TTLPolicyConfig          `yaml:"ttlPolicies,omitempty" json:"ttlPolicies,omitempty"`
+	Namespaces    map[string]NamespaceConfig `yaml:"namespaces,omitempty" json:"namespaces,omitempty"`
+}
+
+// RuleConfig overrides the settings of the rule with the same name.
+type RuleConfig struct {
+	Name     string `yaml:"name" json:"name"`
+	Mode     string `yaml:"mode,omitempty" json:"mode,omitempty"`
+	Disabled bool   `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+}
+
+func LoadRuleSetConfig(path string) (*RuleSetConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config RuleSetConfig
+	if err = yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parse rule set %s: %w", path, err)
+	}
+
+	return &config, nil
+}
+
+// NamespaceConfig holds the settings specific to a game namespace.
+type NamespaceConfig struct {
+	// Locale of the messages returned when the request does not specify one.
+	Locale string `yaml:"locale,omitempty" json:"locale,omitempty"`
+	// Timezone and ResetHour are when the days start for the daily rules,
+	// midnight UTC by default. Timezone is an IANA name, e.g. Asia/Tokyo.
+	Timezone  string `yaml:"timezone,omitempty" json:"timezone,omitempty"`
+	ResetHour int    `yaml:"resetHour,omitempty" json:"resetHour,omitempty"`
+}
+
+// RuleSet is the set of rules the server evaluates records against.
+type RuleSet struct {
+	Version    string
+	Rules      []*Rule
+	Disabled   []*Rule
+	Namespaces map[string]NamespaceConfig
+
+	config        *RuleSetConfig
+	errorPolicies []errorPolicyRule
+	days          map[string]DayConfig
+}
+
+func newRuleSet(rules []*Rule, config *RuleSetConfig) (*RuleSet, error) {
+	if config == nil {
+		config = &RuleSetConfig{}
+	}
+
+	overrides := make(map[string]RuleConfig, len(config.Rules))
+	for _, rc := range config.Rules {
+		if _, found := overrides[rc.Name]; found {
+			return nil, fmt.Errorf("rule %q is configured more than once", rc.Name)
+		}
+		overrides[rc.Name] = rc
+	}
+
+	errorPolicies, err := newErrorPolicyRules(config.ErrorPolicies)
+	if err != nil {
+		return nil, err
+	}
+
+	days := make(map[string]DayConfig, len(config.Namespaces))
+	for namespace, nc := range config.Namespaces {
+		location, err := time.LoadLocation(nc.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("namespace %q: invalid timezone: %w", namespace, err)
+		}
+		if nc.ResetHour < 0 || nc.ResetHour > 23 {
+			return nil, fmt.Errorf("namespace %q: reset hour %d is not between 0 and 23", namespace, nc.ResetHour)
+		}
+		days[namespace] = DayConfig{Location: location, ResetHour: nc.ResetHour}
+	}
+
+	ruleSet := &RuleSet{Version: config.Version, Namespaces: config.Namespaces, config: config, errorPolicies: errorPolicies, days: days}
+	names := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if names[rule.Name] {
+			return nil, fmt.Errorf("rule %q is defined more than once", rule.Name)
+		}
+		names[rule.Name] = true
+
+		rc, found := overrides[rule.Name]
+		delete(overrides, rule.Name)
+
+		if rule.Mode == "" {
+			rule.Mode = ModeEnforce
+		}
+		if found && rc.Disabled {
+			ruleSet.Disabled = append(ruleSet.Disabled, rule)
+
+			continue
+		}
+		if found && rc.Mode != "" {
+			mode, err := ParseMode(rc.Mode)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+			rule.Mode = mode
+		}
+		ruleSet.Rules = append(ruleSet.Rules, rule)
+	}
+
+	for name := range overrides {
+		return nil, fmt.Errorf("unknown rule %q", name)
+	}
+
+	return ruleSet, nil
+}
+
+func (rs *RuleSet) match(hook Hook, key string) []*Rule {
+	var rules []*Rule
+	for _, rule := range rs.Rules {
+		if rule.appliesTo(hook, key) {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+// day returns when the days of namespace start.
+func (rs *RuleSet) day(namespace string) DayConfig {
+	return rs.days[namespace]
+}
+
+// Config returns a copy of the configuration the rule set was built from.
+func (rs *RuleSet) Config() *RuleSetConfig {
+	data, _ := json.Marshal(rs.config)
+
+	var config RuleSetConfig
+	_ = json.Unmarshal(data, &config)
+
+	return &config
+}
+
+// Hash returns the SHA-256 of the configuration of the rule set, so instances
+// running the same configuration can be recognized.
+func (rs *RuleSet) Hash() string {
+	// encoding/json sorts map keys, the encoding is stable
+	data, _ := json.Marshal(rs.config)
+	sum := sha256.Sum256(data)
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
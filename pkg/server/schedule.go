@@ -0,0 +1,278 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/errorcode"
+)
+
+// ScheduleConfig is a rule, named Name, making the records whose key matches
+// KeyPattern available only as scheduled. It is enforced on the read hooks,
+// and also on the write hooks when Write is set.
+//
+//	schedules:
+//	  - name: halloween_schedule
+//	    keyPattern: "*halloween_*"
+//	    notBefore: "2024-10-25T04:00:00+09:00"
+//	    notAfter: "2024-11-01T04:00:00+09:00"
+//	    weekdays: [fri, sat, sun]
+//	    windows:
+//	      - cron: "0 18 * * *"
+//	        duration: 6h
+//	    payloadField: event.availability
+type ScheduleConfig struct {
+	Name         string `yaml:"name" json:"name"`
+	KeyPattern   string `yaml:"keyPattern" json:"keyPattern"`
+	Write        bool   `yaml:"write,omitempty" json:"write,omitempty"`
+	Availability `yaml:",inline"`
+	// Timezone is the IANA time zone of the weekdays and windows, the time
+	// zone of the record's namespace by default.
+	Timezone string `yaml:"timezone,omitempty" json:"timezone,omitempty"`
+	// PayloadField is the dot-separated path of an object of the JSON payload
+	// holding a further Availability of the record, e.g. event.availability.
+	PayloadField string `yaml:"payloadField,omitempty" json:"payloadField,omitempty"`
+}
+
+// Availability is when a record is available. NotBefore and NotAfter are
+// RFC 3339 times. Weekdays, e.g. mon or monday, are days of the namespace, so
+// they start at its reset hour. Windows are recurring periods. Every field is
+// optional and the record must satisfy all that are set.
+type Availability struct {
+	NotBefore string         `yaml:"notBefore,omitempty" json:"notBefore,omitempty"`
+	NotAfter  string         `yaml:"notAfter,omitempty" json:"notAfter,omitempty"`
+	Weekdays  []string       `yaml:"weekdays,omitempty" json:"weekdays,omitempty"`
+	Windows   []WindowConfig `yaml:"windows,omitempty" json:"windows,omitempty"`
+}
+
+// WindowConfig is a period of Duration, e.g. 90m, starting at every time
+// matching the Cron expression of five fields: minute, hour, day of month,
+// month and day of week.
+type WindowConfig struct {
+	Cron     string `yaml:"cron" json:"cron"`
+	Duration string `yaml:"duration" json:"duration"`
+}
+
+// maxWindowDuration bounds the windows, a window is found by walking back
+// its duration from the current time.
+const maxWindowDuration = 31 * 24 * time.Hour
+
+type availability struct {
+	notBefore time.Time
+	notAfter  time.Time
+	weekdays  uint8
+	windows   []window
+}
+
+type window struct {
+	cron     *cronSchedule
+	duration time.Duration
+}
+
+func parseAvailability(a Availability) (*availability, error) {
+	parsed := &availability{}
+	var err error
+	if a.NotBefore != "" {
+		if parsed.notBefore, err = time.Parse(time.RFC3339, a.NotBefore); err != nil {
+			return nil, fmt.Errorf("invalid notBefore: %w", err)
+		}
+	}
+	if a.NotAfter != "" {
+		if parsed.notAfter, err = time.Parse(time.RFC3339, a.NotAfter); err != nil {
+			return nil, fmt.Errorf("invalid notAfter: %w", err)
+		}
+	}
+	if !parsed.notBefore.IsZero() && !parsed.notAfter.IsZero() && !parsed.notAfter.After(parsed.notBefore) {
+		return nil, errors.New("notAfter is not after notBefore")
+	}
+
+	for _, name := range a.Weekdays {
+		weekday, err := parseWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+		parsed.weekdays |= 1 << weekday
+	}
+
+	for i, w := range a.Windows {
+		cron, err := parseCron(w.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("window %d: %w", i, err)
+		}
+		duration, err := time.ParseDuration(w.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("window %d: invalid duration: %w", i, err)
+		}
+		if duration <= 0 || duration > maxWindowDuration {
+			return nil, fmt.Errorf("window %d: duration %s is not between 0 and %s", i, w.Duration, maxWindowDuration)
+		}
+		parsed.windows = append(parsed.windows, window{cron: cron, duration: duration})
+	}
+
+	return parsed, nil
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	name := strings.ToLower(s)
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if name == strings.ToLower(weekday.String()) || name == strings.ToLower(weekday.String()[:3]) {
+			return weekday, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid weekday %q", s)
+}
+
+// check returns a violation when the record of key is not available at now,
+// in the time zone and with the reset hour of day.
+func (a *availability) check(key string, now time.Time, day DayConfig) *Violation {
+	switch {
+	case !a.notBefore.IsZero() && now.Before(a.notBefore):
+		return &Violation{
+			Entry:  errorcode.NotYetAvailable,
+			Params: map[string]any{"key": key, "availableOn": a.notBefore},
+		}
+	case !a.notAfter.IsZero() && !now.Before(a.notAfter):
+		return &Violation{
+			Entry:  errorcode.NoLongerAvailable,
+			Params: map[string]any{"key": key, "availableUntil": a.notAfter},
+		}
+	}
+
+	if a.weekdays != 0 && a.weekdays&(1<<day.day(now).Weekday()) == 0 {
+		return &Violation{Entry: errorcode.OutsideSchedule, Params: map[string]any{"key": key}}
+	}
+	if len(a.windows) > 0 {
+		local := now.In(day.location())
+		for _, w := range a.windows {
+			if w.cron.active(local, w.duration) {
+				return nil
+			}
+		}
+
+		return &Violation{Entry: errorcode.OutsideSchedule, Params: map[string]any{"key": key}}
+	}
+
+	return nil
+}
+
+// scheduleRules returns the rules of the schedules of config.
+func (s *CloudsaveValidatorServer) scheduleRules(config *RuleSetConfig) ([]*Rule, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	rules := make([]*Rule, 0, len(config.Schedules))
+	for i, c := range config.Schedules {
+		if c.Name == "" {
+			return nil, fmt.Errorf("schedule %d: name is required", i)
+		}
+		rule, err := s.scheduleRule(c, config.Version)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", c.Name, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func (s *CloudsaveValidatorServer) scheduleRule(c ScheduleConfig, version string) (*Rule, error) {
+	if c.KeyPattern == "" {
+		return nil, errors.New("key pattern is required")
+	}
+	configured, err := parseAvailability(c.Availability)
+	if err != nil {
+		return nil, err
+	}
+	var location *time.Location
+	if c.Timezone != "" {
+		if location, err = time.LoadLocation(c.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid timezone: %w", err)
+		}
+	}
+
+	var hooks []Hook
+	for _, hook := range Hooks {
+		if hook.Phase() == PhaseRead || c.Write {
+			hooks = append(hooks, hook)
+		}
+	}
+
+	return &Rule{
+		Name:       c.Name,
+		Version:    version,
+		KeyPattern: c.KeyPattern,
+		Hooks:      hooks,
+		Check: func(ctx context.Context, record *Record) (*Violation, error) {
+			now := s.now(ctx)
+			day := s.RuleSet().day(record.Namespace)
+			if location != nil {
+				day.Location = location
+			}
+
+			if violation := configured.check(record.Key, now, day); violation != nil {
+				return violation, nil
+			}
+			if c.PayloadField == "" || record.Payload == nil {
+				return nil, nil
+			}
+
+			fromPayload, err := payloadAvailability(record.Payload, c.PayloadField)
+			if err != nil || fromPayload == nil {
+				return nil, err
+			}
+
+			return fromPayload.check(record.Key, now, day), nil
+		},
+	}, nil
+}
+
+// payloadAvailability returns the Availability at path in the JSON payload,
+// or nil when the payload has no such field.
+func payloadAvailability(payload []byte, path string) (*availability, error) {
+	var value any
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return nil, err
+	}
+	for _, name := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, nil
+		}
+		value = object[name]
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var a Availability
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&a); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid availability in payload field %s: %v", path, err)
+	}
+
+	parsed, err := parseAvailability(a)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid availability in payload field %s: %v", path, err)
+	}
+
+	return parsed, nil
+}
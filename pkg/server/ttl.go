@@ -0,0 +1,188 @@
+// Copyright (c) 2023 AccelByte Inc. All Rights Reserved.
+// This is licensed software from AccelByte Inc, for limitations
+// and restrictions contact your company contract manager.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloudsave-validator-grpc-plugin-server-go/pkg/errorcode"
+)
+
+// TTLPresence tells whether records must have a TTL configuration.
+type TTLPresence string
+
+const (
+	// TTLOptional accepts records with or without a TTL configuration.
+	TTLOptional TTLPresence = "optional"
+	// TTLRequired rejects records without a TTL configuration.
+	TTLRequired TTLPresence = "required"
+	// TTLForbidden rejects records with a TTL configuration.
+	TTLForbidden TTLPresence = "forbidden"
+)
+
+func ParseTTLPresence(s string) (TTLPresence, error) {
+	switch TTLPresence(strings.ToLower(s)) {
+	case "", TTLOptional:
+		return TTLOptional, nil
+	case TTLRequired:
+		return TTLRequired, nil
+	case TTLForbidden:
+		return TTLForbidden, nil
+	default:
+		return "", fmt.Errorf("invalid ttl %q", s)
+	}
+}
+
+// TTLPolicyConfig is a rule, named Name, checking the TTL configuration of
+// the game records and game binary records whose key matches KeyPattern when
+// they are written. Lifetimes are durations, e.g. 72h, from the creation of
+// the record to its expiry.
+//
+//	ttlPolicies:
+//	  - name: event_ttl
+//	    keyPattern: "event_*"
+//	    ttl: required
+//	    minLifetime: 1h
+//	    maxLifetime: 720h
+//	    actions: [DELETE]
+type TTLPolicyConfig struct {
+	Name        string   `yaml:"name" json:"name"`
+	KeyPattern  string   `yaml:"keyPattern" json:"keyPattern"`
+	TTL         string   `yaml:"ttl,omitempty" json:"ttl,omitempty"`
+	MinLifetime string   `yaml:"minLifetime,omitempty" json:"minLifetime,omitempty"`
+	MaxLifetime string   `yaml:"maxLifetime,omitempty" json:"maxLifetime,omitempty"`
+	Actions     []string `yaml:"actions,omitempty" json:"actions,omitempty"`
+}
+
+type ttlPolicy struct {
+	presence    TTLPresence
+	minLifetime time.Duration
+	maxLifetime time.Duration
+	actions     []string
+}
+
+func parseTTLPolicy(c TTLPolicyConfig) (*ttlPolicy, error) {
+	if c.KeyPattern == "" {
+		return nil, errors.New("key pattern is required")
+	}
+
+	presence, err := ParseTTLPresence(c.TTL)
+	if err != nil {
+		return nil, err
+	}
+	policy := &ttlPolicy{presence: presence, actions: c.Actions}
+	if c.MinLifetime != "" {
+		if policy.minLifetime, err = time.ParseDuration(c.MinLifetime); err != nil {
+			return nil, fmt.Errorf("invalid minLifetime: %w", err)
+		}
+	}
+	if c.MaxLifetime != "" {
+		if policy.maxLifetime, err = time.ParseDuration(c.MaxLifetime); err != nil {
+			return nil, fmt.Errorf("invalid maxLifetime: %w", err)
+		}
+	}
+
+	switch {
+	case policy.minLifetime < 0 || policy.maxLifetime < 0:
+		return nil, errors.New("lifetimes cannot be negative")
+	case policy.maxLifetime != 0 && policy.maxLifetime < policy.minLifetime:
+		return nil, errors.New("maxLifetime is less than minLifetime")
+	case presence == TTLForbidden && (policy.minLifetime != 0 || policy.maxLifetime != 0 || len(policy.actions) > 0):
+		return nil, errors.New("a forbidden ttl cannot have lifetimes or actions")
+	}
+
+	return policy, nil
+}
+
+// check returns a violation when the TTL configuration of record does not
+// follow the policy. createdAt is the creation time of the record.
+func (p *ttlPolicy) check(record *Record, createdAt time.Time) *Violation {
+	expiresAt := record.TTLConfig.GetExpiresAt()
+	if expiresAt == nil {
+		if p.presence == TTLRequired {
+			return &Violation{Entry: errorcode.TTLRequired, Params: map[string]any{"key": record.Key}}
+		}
+
+		return nil
+	}
+	if p.presence == TTLForbidden {
+		return &Violation{Entry: errorcode.TTLForbidden, Params: map[string]any{"key": record.Key}}
+	}
+
+	lifetime := expiresAt.AsTime().Sub(createdAt)
+	switch {
+	case lifetime <= 0:
+		return &Violation{
+			Entry:  errorcode.TTLBeforeCreation,
+			Params: map[string]any{"key": record.Key, "expiresAt": expiresAt.AsTime(), "createdAt": createdAt},
+		}
+	case lifetime < p.minLifetime:
+		return &Violation{
+			Entry:  errorcode.TTLTooShort,
+			Params: map[string]any{"key": record.Key, "limit": p.minLifetime, "lifetime": lifetime.Round(time.Second)},
+		}
+	case p.maxLifetime != 0 && lifetime > p.maxLifetime:
+		return &Violation{
+			Entry:  errorcode.TTLTooLong,
+			Params: map[string]any{"key": record.Key, "limit": p.maxLifetime, "lifetime": lifetime.Round(time.Second)},
+		}
+	}
+
+	if len(p.actions) > 0 {
+		action := record.TTLConfig.GetAction()
+		for _, allowed := range p.actions {
+			if strings.EqualFold(action, allowed) {
+				return nil
+			}
+		}
+
+		return &Violation{
+			Entry:  errorcode.TTLActionNotAllowed,
+			Params: map[string]any{"key": record.Key, "action": action, "actions": strings.Join(p.actions, ", ")},
+		}
+	}
+
+	return nil
+}
+
+// ttlPolicyRules returns the rules of the TTL policies of config.
+func (s *CloudsaveValidatorServer) ttlPolicyRules(config *RuleSetConfig) ([]*Rule, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	rules := make([]*Rule, 0, len(config.TTLPolicies))
+	for i, c := range config.TTLPolicies {
+		if c.Name == "" {
+			return nil, fmt.Errorf("ttl policy %d: name is required", i)
+		}
+		policy, err := parseTTLPolicy(c)
+		if err != nil {
+			return nil, fmt.Errorf("ttl policy %q: %w", c.Name, err)
+		}
+
+		rules = append(rules, &Rule{
+			Name:       c.Name,
+			Version:    config.Version,
+			KeyPattern: c.KeyPattern,
+			Hooks:      []Hook{HookBeforeWriteGameRecord, HookBeforeWriteGameBinaryRecord},
+			Check: func(ctx context.Context, record *Record) (*Violation, error) {
+				// a record being created has no creation time yet
+				createdAt := s.now(ctx)
+				if record.CreatedAt.GetSeconds() > 0 {
+					createdAt = record.CreatedAt.AsTime()
+				}
+
+				return policy.check(record, createdAt), nil
+			},
+		})
+	}
+
+	return rules, nil
+}